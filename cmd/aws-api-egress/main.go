@@ -2,92 +2,127 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
 	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
 	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
 )
 
-// Rule configuration constants for AWS APIs.
-const (
-	httpsPort    = 443
-	ruleProtocol = rule.ProtocolTCP
+var (
+	sess    = session.New()
+	clients = rule.NewClients(sess)
 )
 
-var ec2Client = ec2.New(session.New())
-
 // Event is passed into the lambda function at runtime.
 type Event struct {
-	// Services are AWS services to whitelist.
-	// See  https://docs.aws.amazon.com/general/latest/gr/aws-ip-ranges.html
-	// for a complete list of services.
-	Services []string `json:"services"`
+	// AWSServices are the AWS services and regions to whitelist, and the
+	// IP ranges file configuration.
+	source.AWSServices
 
-	// Regions are the regions to whitelist.
-	Regions []string `json:"regions"`
+	// Targets are the security groups, and other resources such as prefix
+	// lists, to apply them to.
+	rule.Targets
 
-	// SecurityGroups are the security groups to apply them to.
-	SecurityGroups []string `json:"securityGroups"`
+	// Options configure how rules are applied to the security groups.
+	rule.Options
 }
 
-// Service is an AWS service.
-type Service struct {
-	Name  string
-	CIDRs []string
-}
+// lastSyncTokens are the syncTokens of the IP ranges which previous
+// invocations in a warm lambda container applied to every target of an event,
+// keyed by IP ranges URL and event. An event is only in the map if its last
+// invocation succeeded.
+var lastSyncTokens = make(map[string]string)
 
 func main() {
 	lambda.Start(lambdaHandler)
 }
 
-func lambdaHandler(_ context.Context, evt Event) (string, error) {
-	getter := awsips.NewIPRangesGetter(awsips.IPRangesFile, evt.Regions)
-
-	services := make([]Service, 0)
+// lambdaHandler handles both scheduled events, which contain an Event, and
+// AmazonIpSpaceChanged SNS notifications. The Event for notifications is read
+// from the environment.
+func lambdaHandler(_ context.Context, payload json.RawMessage) (string, error) {
+	notification, err := parseNotification(payload)
+	if err != nil {
+		log.Printf("Failed to parse notification: %+v", err)
+		return awshelpers.LambdaOutput(err)
+	}
 
-	for _, svc := range evt.Services {
-		cidrs, err := getter.GetService(svc)
-		if err != nil {
-			log.Printf("Failed to read CIDRs for service %s: %+v", svc, err)
+	if notification == nil {
+		evt := Event{}
+		if err := json.Unmarshal(payload, &evt); err != nil {
 			return awshelpers.LambdaOutput(err)
 		}
 
-		services = append(services, Service{
-			Name:  svc,
-			CIDRs: cidrs,
-		})
+		return reconcile(evt, evt.Source())
 	}
 
-	rules := make([]rule.Rule, len(services))
-	for i := range services {
-		rules[i] = rule.Rule{
-			Name:     services[i].Name,
-			Port:     httpsPort,
-			Protocol: rule.ProtocolTCP,
-			Egress:   true,
-			CIDRs:    services[i].CIDRs,
-		}
+	evt, err := notificationEvent()
+	if err != nil {
+		log.Printf("Failed to read event: %+v", err)
+		return awshelpers.LambdaOutput(err)
 	}
 
-	errs := make([]error, 0)
-	for _, sgid := range evt.SecurityGroups {
-		sg, err := awshelpers.DescribeSecurityGroup(sgid, ec2Client)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
+	if err := notification.CheckURL(evt.Source()); err != nil {
+		log.Printf("Rejected notification: %+v", err)
+		return awshelpers.LambdaOutput(err)
+	}
 
-		if err := rule.Add(rules, sg, ec2Client); err != nil {
-			log.Printf("Failed to add rules: %+v", err)
-			errs = append(errs, err)
-		}
+	log.Printf("IP space changed to syncToken %s", notification.SyncToken)
+
+	return reconcile(evt, notification.URL, awsips.WithMD5(notification.MD5))
+}
+
+// reconcile applies rules for an event using the IP ranges file at url.
+func reconcile(evt Event, url string, opts ...awsips.Option) (string, error) {
+	if err := evt.Validate(); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+	// AWS services have no domains.
+	if err := evt.ValidateDomainTargets(false, evt.Options); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
 
-		if err := rule.Cleanup(rules, sg, ec2Client); err != nil {
-			log.Printf("Failed to clean up rules: %+v", err)
+	rules, getter, err := evt.AWSServices.Resolve(url, awshelpers.CurrentRegion(sess), opts...)
+	if err != nil {
+		log.Printf("Failed to resolve services: %+v", err)
+		return awshelpers.LambdaOutput(err)
+	}
+
+	ranges, err := getter.Get()
+	if err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+	key := url + " " + string(data)
+
+	detail := fmt.Sprintf("ip-ranges from %s (syncToken %s)", getter.Source(), ranges.SyncToken)
+
+	targets := evt.Build(clients)
+	applyOpts := awsips.SnapshotOptions(getter.Source(), evt.Options)
+
+	// If the event and the IP ranges are unchanged since the last
+	// successful invocation, targets are only applied again if they were
+	// changed by something else since.
+	if last, ok := lastSyncTokens[key]; ok && getter.Unchanged() && last == ranges.SyncToken && inSync(targets, rules, applyOpts) {
+		log.Printf("IP ranges unchanged since syncToken %s and every target is in sync", ranges.SyncToken)
+		return awshelpers.LambdaOutputDetail(detail, nil)
+	}
+	delete(lastSyncTokens, key)
+
+	errs := make([]error, 0)
+	for _, target := range targets {
+		if err := target.Apply(rules, applyOpts); err != nil {
+			log.Printf("Failed to apply rules to %s: %+v", target, err)
 			errs = append(errs, err)
 		}
 	}
@@ -96,5 +131,20 @@ func lambdaHandler(_ context.Context, evt Event) (string, error) {
 		return awshelpers.LambdaOutput(errs[0])
 	}
 
-	return awshelpers.LambdaOutput(nil)
+	lastSyncTokens[key] = ranges.SyncToken
+	log.Printf("Applied %s", detail)
+
+	return awshelpers.LambdaOutputDetail(detail, nil)
+}
+
+// inSync returns a boolean for whether applying the rules would not change
+// any of the targets.
+func inSync(targets []rule.Target, rules []rule.Rule, opts rule.Options) bool {
+	for _, target := range targets {
+		changes, err := target.Plan(rules, opts)
+		if err != nil || len(changes) > 0 {
+			return false
+		}
+	}
+	return true
 }
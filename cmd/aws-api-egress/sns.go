@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+)
+
+// eventEnv is the environment variable containing the Event to apply when
+// the function is invoked by an SNS notification.
+const eventEnv = "EGRESS_EVENT"
+
+// snsEventSource is the event source of SNS records.
+const snsEventSource = "aws:sns"
+
+// parseNotification parses an AmazonIpSpaceChanged SNS notification. If the
+// payload is not an SNS event, nil is returned.
+func parseNotification(payload json.RawMessage) (*awsips.IPSpaceChanged, error) {
+	evt := events.SNSEvent{}
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		return nil, nil
+	}
+
+	if len(evt.Records) == 0 || evt.Records[0].EventSource != snsEventSource {
+		return nil, nil
+	}
+
+	if len(evt.Records) != 1 {
+		return nil, fmt.Errorf("unexpected number of SNS records: %d", len(evt.Records))
+	}
+
+	record := evt.Records[0]
+	if err := awsips.CheckTopic(record.SNS.TopicArn); err != nil {
+		return nil, err
+	}
+
+	return awsips.ParseIPSpaceChanged(record.SNS.Message)
+}
+
+// notificationEvent reads the Event to apply for SNS notifications from the
+// environment. A sha256 digest cannot be configured, since it would not match
+// the file after it changes.
+func notificationEvent() (Event, error) {
+	evt := Event{}
+
+	data := os.Getenv(eventEnv)
+	if data == "" {
+		return evt, errors.New(eventEnv + " is not set")
+	}
+
+	if err := json.Unmarshal([]byte(data), &evt); err != nil {
+		return evt, err
+	}
+
+	if evt.SHA256 != "" {
+		return evt, errors.New(eventEnv + " cannot set sha256; files are verified against the md5 in the notification")
+	}
+
+	return evt, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/config"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
+)
+
+// configEnv is the environment variable containing the config location to
+// use when the event does not have one.
+const configEnv = "DSG_CONFIG"
+
+var (
+	sess    = session.New()
+	clients = rule.NewClients(sess)
+	loader  = &config.Loader{Session: sess}
+)
+
+// Event is passed into the lambda function at runtime.
+type Event struct {
+	// Config is the location of the config: a file in the deployment
+	// package, an s3://bucket/key URL or an ssm://name parameter. Defaults
+	// to the DSG_CONFIG environment variable.
+	Config string `json:"config,omitempty"`
+
+	// Bindings are the names of the bindings to apply. Every binding is
+	// applied if empty.
+	Bindings []string `json:"bindings,omitempty"`
+}
+
+func main() {
+	lambda.Start(lambdaHandler)
+}
+
+func lambdaHandler(_ context.Context, evt Event) (string, error) {
+	location := evt.Config
+	if location == "" {
+		location = os.Getenv(configEnv)
+	}
+	if location == "" {
+		return awshelpers.LambdaOutput(fmt.Errorf("the event has no config and %s is not set", configEnv))
+	}
+
+	// The config is read on every invocation so changes take effect
+	// without redeploying the function.
+	cfg, err := loader.Load(location)
+	if err != nil {
+		log.Printf("Failed to load config: %+v", err)
+		return awshelpers.LambdaOutput(err)
+	}
+
+	bindings, err := cfg.Select(evt.Bindings)
+	if err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+
+	resolver := &source.Resolver{
+		IPRanges:      cfg.IPRanges,
+		CurrentRegion: awshelpers.CurrentRegion(sess),
+	}
+
+	// Every rule set is resolved before any target is changed, so a
+	// failed lookup never removes rules.
+	jobs, err := cfg.Jobs(bindings, resolver, clients)
+	if err != nil {
+		log.Printf("Failed to resolve bindings: %+v", err)
+		return awshelpers.LambdaOutput(err)
+	}
+
+	errs := make([]error, 0)
+	for _, job := range jobs {
+		for _, target := range job.Targets {
+			if err := target.Apply(job.Rules, job.Options); err != nil {
+				log.Printf("Binding %s: failed to apply rules to %s: %+v", job.Binding, target, err)
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return awshelpers.LambdaOutput(errs[0])
+	}
+
+	return awshelpers.LambdaOutput(nil)
+}
@@ -6,20 +6,23 @@ import (
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
 	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
 )
 
-var ec2Client = ec2.New(session.New())
+var clients = rule.NewClients(session.New())
 
 // Event is passed into the lambda function at runtime.
 type Event struct {
 	// Rules are the rules to apply.
 	Rules []rule.Rule `json:"rules"`
 
-	// SecurityGroups are the security groups to apply them to.
-	SecurityGroups []string `json:"securityGroups"`
+	// Targets are the security groups, and other resources such as DNS
+	// Firewall domain lists, to apply them to.
+	rule.Targets
+
+	// Options configure how rules are applied to the security groups.
+	rule.Options
 }
 
 func main() {
@@ -27,24 +30,25 @@ func main() {
 }
 
 func lambdaHandler(_ context.Context, evt Event) (string, error) {
-	rules := make([]rule.Rule, len(evt.Rules))
-	copy(rules, evt.Rules)
-
-	errs := make([]error, 0)
-	for _, sgid := range evt.SecurityGroups {
-		sg, err := awshelpers.DescribeSecurityGroup(sgid, ec2Client)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
+	if err := evt.Validate(); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+	if err := evt.ValidateDomainTargets(len(evt.Rules) > 0, evt.Options); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
 
-		if err := rule.Add(rules, sg, ec2Client); err != nil {
-			log.Printf("Failed to add rules: %+v", err)
-			errs = append(errs, err)
-		}
+	// Rules are resolved up front so that Cleanup can compare against
+	// their CIDRs.
+	rules, err := rule.ResolveRules(evt.Rules)
+	if err != nil {
+		log.Printf("Failed to resolve rules: %+v", err)
+		return awshelpers.LambdaOutput(err)
+	}
 
-		if err := rule.Cleanup(rules, sg, ec2Client); err != nil {
-			log.Printf("Failed to clean up rules: %+v", err)
+	errs := make([]error, 0)
+	for _, target := range evt.Build(clients) {
+		if err := target.Apply(rules, evt.Options); err != nil {
+			log.Printf("Failed to apply rules to %s: %+v", target, err)
 			errs = append(errs, err)
 		}
 	}
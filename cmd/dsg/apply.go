@@ -0,0 +1,312 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/config"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
+)
+
+// awsFlags are the flags shared by commands which read an event and call AWS.
+type awsFlags struct {
+	profile string
+	region  string
+	jsonOut bool
+
+	// config and bindings are only registered by plan and apply.
+	config   string
+	bindings string
+}
+
+// register adds the flags to a flag set.
+func (f *awsFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.profile, "profile", "", "shared config `profile`; defaults to AWS_PROFILE or default")
+	fs.StringVar(&f.region, "region", "", "AWS `region`, which is also @current in awsService sources; defaults to the profile's region")
+	fs.BoolVar(&f.jsonOut, "json", false, "write JSON output")
+}
+
+// registerConfig adds the flags which read a config instead of an event.
+func (f *awsFlags) registerConfig(fs *flag.FlagSet) {
+	fs.StringVar(&f.config, "config", "", "config `location` of rule sets and bindings to use instead of an event: a file, s3://bucket/key or ssm://name")
+	fs.StringVar(&f.bindings, "bindings", "", "comma separated `names` of the config bindings to use; defaults to all")
+}
+
+// session creates a session with the standard credential chain and shared
+// config files.
+func (f *awsFlags) session() (*session.Session, error) {
+	opts := session.Options{
+		Profile:           f.profile,
+		SharedConfigState: session.SharedConfigEnable,
+	}
+	if f.region != "" {
+		opts.Config.Region = aws.String(f.region)
+	}
+
+	return session.NewSessionWithOptions(opts)
+}
+
+// currentRegion returns the region of the session.
+func (f *awsFlags) currentRegion(sess *session.Session) string {
+	if f.region != "" {
+		return f.region
+	}
+	return awshelpers.CurrentRegion(sess)
+}
+
+// load reads and resolves the event named by the only argument, and returns
+// the options to apply its rules with.
+func (f *awsFlags) load(fs *flag.FlagSet, sess *session.Session) (*event, []rule.Rule, rule.Options, error) {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return nil, nil, rule.Options{}, errors.New("expected an event file")
+	}
+
+	evt, err := readEvent(fs.Arg(0))
+	if err != nil {
+		return nil, nil, rule.Options{}, err
+	}
+
+	rules, opts, err := evt.resolve(f.currentRegion(sess))
+	if err != nil {
+		return nil, nil, rule.Options{}, err
+	}
+
+	return evt, rules, opts, nil
+}
+
+func runResolve(args []string) error {
+	f := &awsFlags{}
+	fs := flag.NewFlagSet("resolve", flag.ExitOnError)
+	f.register(fs)
+	fs.Usage = eventUsage(fs, "Its rules are resolved and printed.")
+	fs.Parse(args)
+
+	sess, err := f.session()
+	if err != nil {
+		return err
+	}
+
+	_, rules, _, err := f.load(fs, sess)
+	if err != nil {
+		return err
+	}
+
+	if f.jsonOut {
+		return printJSON(rules)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tDIRECTION\tPROTOCOL\tPORT\tCIDR")
+	for _, r := range rules {
+		direction := rule.DirectionIngress
+		if r.Egress {
+			direction = rule.DirectionEgress
+		}
+
+		cidrs := r.CIDRs
+		if len(cidrs) == 0 {
+			cidrs = []string{"-"}
+		}
+		for _, cidr := range cidrs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", r.Name, direction, r.Protocol, r.Port, cidr)
+		}
+	}
+
+	return w.Flush()
+}
+
+func runPlan(args []string) error {
+	f := &awsFlags{}
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	f.register(fs)
+	f.registerConfig(fs)
+	fs.Usage = eventUsage(fs, "Its rules are resolved, and the changes which applying them would make to its targets are printed. "+
+		"With -config, the bindings of a config are planned instead of an event.")
+	fs.Parse(args)
+
+	return f.apply(fs, true)
+}
+
+func runApply(args []string) error {
+	var dryRun bool
+
+	f := &awsFlags{}
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	f.register(fs)
+	f.registerConfig(fs)
+	fs.BoolVar(&dryRun, "dry-run", false, "print the changes without making them, like plan")
+	fs.Usage = eventUsage(fs, "Its rules are resolved and applied to its targets, and the changes are printed. "+
+		"With -config, the bindings of a config are applied instead of an event.")
+	fs.Parse(args)
+
+	return f.apply(fs, dryRun)
+}
+
+// apply applies the event or config, unless dryRun is set, and prints the
+// changes.
+func (f *awsFlags) apply(fs *flag.FlagSet, dryRun bool) error {
+	sess, err := f.session()
+	if err != nil {
+		return err
+	}
+
+	var changes []rule.Change
+	var applyErr error
+
+	if f.config != "" {
+		jobs, err := f.loadConfig(fs, sess)
+		if err != nil {
+			return err
+		}
+		changes, applyErr = applyJobs(jobs, dryRun)
+	} else {
+		evt, rules, opts, err := f.load(fs, sess)
+		if err != nil {
+			return err
+		}
+		changes, applyErr = applyEvent(evt, rules, opts, rule.NewClients(sess), dryRun)
+	}
+
+	if err := printChanges(changes, f.jsonOut); err != nil {
+		return err
+	}
+
+	return applyErr
+}
+
+// applyEvent plans the changes to each target of an event, and applies them
+// with opts unless dryRun is set.
+func applyEvent(evt *event, rules []rule.Rule, opts rule.Options, clients rule.Clients, dryRun bool) ([]rule.Change, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := evt.ValidateDomainTargets(len(rule.Domains(rules)) > 0, opts); err != nil {
+		return nil, err
+	}
+
+	targets := evt.Build(clients)
+	if len(targets) == 0 {
+		return nil, errors.New("the event has no targets")
+	}
+
+	return applyTargets(targets, rules, opts, dryRun)
+}
+
+// loadConfig reads the config, and resolves the rules and targets of the
+// selected bindings.
+func (f *awsFlags) loadConfig(fs *flag.FlagSet, sess *session.Session) ([]config.Job, error) {
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return nil, errors.New("an event file cannot be used with -config")
+	}
+
+	loader := &config.Loader{Session: sess}
+	cfg, err := loader.Load(f.config)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	if f.bindings != "" {
+		names = strings.Split(f.bindings, ",")
+	}
+	bindings, err := cfg.Select(names)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &source.Resolver{IPRanges: cfg.IPRanges, CurrentRegion: f.currentRegion(sess)}
+	return cfg.Jobs(bindings, resolver, rule.NewClients(sess))
+}
+
+// applyJobs applies the targets of each job, unless dryRun is set.
+func applyJobs(jobs []config.Job, dryRun bool) ([]rule.Change, error) {
+	changes := make([]rule.Change, 0)
+	failed := make([]string, 0)
+	for _, job := range jobs {
+		planned, err := applyTargets(job.Targets, job.Rules, job.Options, dryRun)
+		changes = append(changes, planned...)
+		if err != nil {
+			failed = append(failed, job.Binding)
+		}
+	}
+
+	if len(failed) > 0 {
+		return changes, fmt.Errorf("failed to apply bindings %s", strings.Join(failed, ", "))
+	}
+
+	return changes, nil
+}
+
+// applyTargets plans the changes to each target, and applies them unless
+// dryRun is set. Every target is attempted even if another one fails.
+func applyTargets(targets []rule.Target, rules []rule.Rule, opts rule.Options, dryRun bool) ([]rule.Change, error) {
+	changes := make([]rule.Change, 0)
+	failed := make([]string, 0)
+
+	for _, target := range targets {
+		planned, err := target.Plan(rules, opts)
+		changes = append(changes, planned...)
+
+		if err == nil && !dryRun {
+			err = target.Apply(rules, opts)
+		}
+		if err != nil {
+			log.Printf("Failed to apply rules to %s: %v", target, err)
+			failed = append(failed, target.String())
+		}
+	}
+
+	if len(failed) > 0 {
+		return changes, fmt.Errorf("failed to apply rules to %s", strings.Join(failed, ", "))
+	}
+
+	return changes, nil
+}
+
+// printChanges prints changes as a table, or a JSON array.
+func printChanges(changes []rule.Change, jsonOut bool) error {
+	rule.SortChanges(changes)
+
+	if jsonOut {
+		return printJSON(changes)
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TARGET\tACTION\tDIRECTION\tPROTOCOL\tPORT\tVALUE\tDESCRIPTION")
+	for _, c := range changes {
+		port := "-"
+		if c.Port != 0 {
+			port = fmt.Sprint(c.Port)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Target, c.Action, orDash(c.Direction), orDash(c.Protocol), port, c.Value, orDash(c.Description))
+	}
+
+	return w.Flush()
+}
+
+// orDash returns s, or a dash if s is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/daemon"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// shutdownTimeout is how long the HTTP endpoint waits for requests to finish
+// when the daemon stops.
+const shutdownTimeout = 5 * time.Second
+
+// readDaemonConfig reads a daemon configuration file, and decodes the event of
+// every job so that invalid events are rejected before the daemon starts.
+func readDaemonConfig(path string) (*daemon.Config, map[string]*event, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &daemon.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	events := make(map[string]*event)
+	for _, job := range config.Jobs {
+		evt := &event{}
+		if err := json.Unmarshal(job.Event, evt); err != nil {
+			return nil, nil, fmt.Errorf("job %s: %v", job.Name, err)
+		}
+		if err := evt.Options.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("job %s: %v", job.Name, err)
+		}
+		events[job.Name] = evt
+	}
+
+	return config, events, nil
+}
+
+func runDaemon(args []string) error {
+	var dryRun bool
+	var listen string
+
+	f := &awsFlags{}
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	f.register(fs)
+	fs.BoolVar(&dryRun, "dry-run", false, "log the changes of each run without making them")
+	fs.StringVar(&listen, "listen", "", "`address` of the health and status endpoint; overrides the config")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dsg daemon [flags] CONFIG")
+		fmt.Fprintln(fs.Output(), "CONFIG is a file of jobs, each with a name, an interval and a dns-firewall, dynamic-firewall or aws-api-egress event. "+
+			"Each job is applied on its interval until the daemon is interrupted, and /healthz and /status report the last runs.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected a config file")
+	}
+
+	config, events, err := readDaemonConfig(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if listen != "" {
+		config.Listen = listen
+	}
+
+	sess, err := f.session()
+	if err != nil {
+		return err
+	}
+	clients := rule.NewClients(sess)
+	region := f.currentRegion(sess)
+
+	d, err := daemon.New(*config, func(_ context.Context, job daemon.Job) error {
+		evt := events[job.Name]
+
+		rules, opts, err := evt.resolve(region)
+		if err != nil {
+			return err
+		}
+
+		changes, err := applyEvent(evt, rules, opts, clients, dryRun)
+		rule.SortChanges(changes)
+		for _, c := range changes {
+			log.Printf("Job %s: %s %s %s %s/%d %s", job.Name, c.Action, c.Target, c.Direction, c.Protocol, c.Port, c.Value)
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Addr: config.ListenAddress(), Handler: d.Handler()}
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Serving health and status on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			serveErr <- err
+			stop()
+		}
+	}()
+
+	d.Run(ctx)
+	log.Print("Stopped all jobs")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-serveErr:
+		return err
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+)
+
+func runDiff(args []string) error {
+	var (
+		eventFile string
+		services  string
+		regions   string
+		jsonOut   bool
+	)
+
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.StringVar(&eventFile, "event", "", "restrict to the services and regions of an aws-api-egress event `file`")
+	fs.StringVar(&services, "services", "", "restrict to a comma separated `list` of services")
+	fs.StringVar(&regions, "regions", "", "restrict to a comma separated `list` of region selectors")
+	fs.BoolVar(&jsonOut, "json", false, "write JSON output")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dsg diff [flags] OLD NEW")
+		fmt.Fprintln(fs.Output(), "OLD and NEW are IP ranges file paths or URLs.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("expected two IP ranges files")
+	}
+
+	var filter *awsips.Filter
+	if eventFile != "" {
+		data, err := ioutil.ReadFile(eventFile)
+		if err != nil {
+			return err
+		}
+
+		filter = &awsips.Filter{}
+		if err := json.Unmarshal(data, filter); err != nil {
+			return err
+		}
+	}
+
+	if services != "" || regions != "" {
+		if filter == nil {
+			filter = &awsips.Filter{}
+		}
+		if services != "" {
+			filter.Services = make([]awsips.Service, 0)
+			for _, name := range strings.Split(services, ",") {
+				filter.Services = append(filter.Services, awsips.Service{Name: name})
+			}
+		}
+		if regions != "" {
+			filter.Regions = strings.Split(regions, ",")
+		}
+	}
+
+	if filter != nil {
+		resolved, err := awsips.ResolveRegions(filter.Regions, awshelpers.CurrentRegion(nil), nil)
+		if err != nil {
+			return err
+		}
+		filter.Regions = resolved
+	}
+
+	oldRanges, err := awsips.NewIPRangesGetter(sourceURL(fs.Arg(0)), nil).Get()
+	if err != nil {
+		return err
+	}
+
+	newRanges, err := awsips.NewIPRangesGetter(sourceURL(fs.Arg(1)), nil).Get()
+	if err != nil {
+		return err
+	}
+
+	diff, err := awsips.Compare(oldRanges, newRanges, filter)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return printJSON(diff)
+	}
+
+	fmt.Printf("syncToken %s -> %s\n", diff.OldSyncToken, diff.NewSyncToken)
+	if diff.Empty() {
+		fmt.Println("No changes")
+		return nil
+	}
+
+	for _, change := range diff.Changes {
+		fmt.Printf("%s %s\n", change.Service, change.Region)
+		for _, cidr := range change.Added {
+			fmt.Printf("  + %s\n", cidr)
+		}
+		for _, cidr := range change.Removed {
+			fmt.Printf("  - %s\n", cidr)
+		}
+	}
+
+	return nil
+}
+
+// sourceURL converts a local path to a file:// URL. URLs are returned as is.
+func sourceURL(src string) string {
+	if strings.Contains(src, "://") {
+		return src
+	}
+	return "file://" + src
+}
@@ -0,0 +1,101 @@
+// Command dsg is a command line interface for dynamic security groups.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command is a dsg subcommand.
+type command struct {
+	// usage is a one line description of the command.
+	usage string
+
+	// run runs the command with its arguments.
+	run func(args []string) error
+}
+
+// commands are the available subcommands, keyed by name.
+var commands = map[string]command{
+	"services": {
+		usage: "List the services in the AWS IP ranges file",
+		run:   runServices,
+	},
+	"regions": {
+		usage: "List the regions in the AWS IP ranges file",
+		run:   runRegions,
+	},
+	"diff": {
+		usage: "Compare two versions of the AWS IP ranges file",
+		run:   runDiff,
+	},
+	"counts": {
+		usage: "Count the prefixes for each service and region in the AWS IP ranges file",
+		run:   runCounts,
+	},
+	"export": {
+		usage: "Export the rules of an event as Terraform or CloudFormation resources",
+		run:   runExport,
+	},
+	"resolve": {
+		usage: "Resolve and print the rules of an event",
+		run:   runResolve,
+	},
+	"plan": {
+		usage: "Print the changes which applying an event would make to its targets",
+		run:   runPlan,
+	},
+	"apply": {
+		usage: "Apply the rules of an event to its targets, and print the changes",
+		run:   runApply,
+	},
+	"daemon": {
+		usage: "Apply the events of a config file on their intervals until interrupted",
+		run:   runDaemon,
+	},
+	"render": {
+		usage: "Render the rules of an event as host firewall configuration or network policies",
+		run:   runRender,
+	},
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "dsg: unknown command %q\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "dsg %s: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+}
+
+// usage prints the available commands.
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: dsg <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+)
+
+// rangesFlags are the flags shared by commands which read the AWS IP ranges
+// file.
+type rangesFlags struct {
+	url     string
+	jsonOut bool
+}
+
+// register adds the flags to a flag set.
+func (f *rangesFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.url, "url", awsips.IPRangesFile, "IP ranges file `url`; http(s)://, file:// or s3://")
+	fs.BoolVar(&f.jsonOut, "json", false, "write JSON output")
+}
+
+// getter creates an IPRangesGetter for the file, and gets it. If the file
+// cannot be read the compiled-in snapshot is used, with a warning.
+func (f *rangesFlags) getter() (*awsips.IPRangesGetter, error) {
+	getter := awsips.NewIPRangesGetter(f.url, nil, awsips.WithSnapshotFallback())
+	if _, err := getter.Get(); err != nil {
+		return nil, err
+	}
+
+	if getter.Source() == awsips.SourceSnapshot {
+		fmt.Fprintf(os.Stderr, "WARNING: %s could not be read; showing the compiled-in snapshot, which may be out of date\n", f.url)
+	}
+
+	return getter, nil
+}
+
+func runServices(args []string) error {
+	f := &rangesFlags{}
+	fs := flag.NewFlagSet("services", flag.ExitOnError)
+	f.register(fs)
+	fs.Parse(args)
+
+	getter, err := f.getter()
+	if err != nil {
+		return err
+	}
+
+	services, err := getter.Services()
+	if err != nil {
+		return err
+	}
+
+	return printList(services, f.jsonOut)
+}
+
+func runRegions(args []string) error {
+	f := &rangesFlags{}
+	fs := flag.NewFlagSet("regions", flag.ExitOnError)
+	f.register(fs)
+	fs.Parse(args)
+
+	getter, err := f.getter()
+	if err != nil {
+		return err
+	}
+
+	regions, err := getter.Regions()
+	if err != nil {
+		return err
+	}
+
+	return printList(regions, f.jsonOut)
+}
+
+func runCounts(args []string) error {
+	f := &rangesFlags{}
+	fs := flag.NewFlagSet("counts", flag.ExitOnError)
+	f.register(fs)
+	fs.Parse(args)
+
+	getter, err := f.getter()
+	if err != nil {
+		return err
+	}
+
+	counts, err := getter.Counts()
+	if err != nil {
+		return err
+	}
+
+	if f.jsonOut {
+		return printJSON(counts)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SERVICE\tREGION\tPREFIXES")
+	for _, count := range counts {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", count.Service, count.Region, count.Prefixes)
+	}
+
+	return w.Flush()
+}
+
+// printList prints one item per line, or a JSON array.
+func printList(items []string, jsonOut bool) error {
+	if jsonOut {
+		return printJSON(items)
+	}
+
+	for _, item := range items {
+		fmt.Println(item)
+	}
+
+	return nil
+}
+
+// printJSON prints a value as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/render"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
+)
+
+// event is a dynamic-firewall, dns-firewall or aws-api-egress event.
+type event struct {
+	// Sources and IPRanges are set in dynamic-firewall events.
+	Sources  []source.Source `json:"sources"`
+	IPRanges *awsips.Config  `json:"ipRanges"`
+
+	// Rules are set in dns-firewall events.
+	Rules []rule.Rule `json:"rules"`
+
+	// AWSServices are set in aws-api-egress events.
+	source.AWSServices
+
+	// Targets and Options are set in every event, and are used by the
+	// plan and apply commands.
+	rule.Targets
+	rule.Options
+}
+
+// readEvent reads an event file, or stdin if path is -.
+func readEvent(path string) (*event, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	evt := &event{}
+	if err := json.Unmarshal(data, evt); err != nil {
+		return nil, err
+	}
+
+	return evt, nil
+}
+
+// resolve resolves the rules of the event with the same functions as the
+// lambda of each kind of event, so that commands show the rules the lambda
+// would apply. The options to apply the rules with are also returned, since
+// no rules are removed if IP ranges came from the compiled-in snapshot.
+func (e *event) resolve(region string) ([]rule.Rule, rule.Options, error) {
+	rules := make([]rule.Rule, 0)
+	opts := e.Options
+
+	if len(e.Sources) > 0 {
+		resolver := &source.Resolver{CurrentRegion: region}
+		if e.IPRanges != nil {
+			resolver.IPRanges = *e.IPRanges
+		}
+
+		generated, err := resolver.Resolve(e.Sources)
+		if err != nil {
+			return nil, opts, err
+		}
+		rules = append(rules, generated...)
+		opts = resolver.Options(opts)
+	}
+
+	if len(e.Rules) > 0 {
+		generated, err := rule.ResolveRules(e.Rules)
+		if err != nil {
+			return nil, opts, err
+		}
+		rules = append(rules, generated...)
+	}
+
+	if len(e.Services) > 0 {
+		generated, getter, err := e.AWSServices.Resolve(e.Source(), region)
+		if err != nil {
+			return nil, opts, err
+		}
+		rules = append(rules, generated...)
+		opts = awsips.SnapshotOptions(getter.Source(), opts)
+	}
+
+	return rules, opts, nil
+}
+
+// labels parses a comma separated list of key=value labels.
+func labels(s string) (map[string]string, error) {
+	result := make(map[string]string)
+	if s == "" {
+		return result, nil
+	}
+
+	for _, label := range strings.Split(s, ",") {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", label)
+		}
+		result[parts[0]] = parts[1]
+	}
+
+	return result, nil
+}
+
+// renderFormats are the formats of the render command, and exportFormats are
+// the formats of the export command.
+var (
+	renderFormats = []string{
+		render.FormatNftables,
+		render.FormatIPSet,
+		render.FormatIPTables,
+		render.FormatNetworkPolicy,
+		render.FormatCilium,
+	}
+	exportFormats = []string{
+		render.FormatTerraform,
+		render.FormatTerraformJSON,
+		render.FormatCloudFormation,
+	}
+)
+
+// renderFlags are the flags shared by the render and export commands.
+type renderFlags struct {
+	format string
+	out    string
+	region string
+	opts   render.Options
+}
+
+// register adds the flags to a flag set, with the formats of the command.
+func (f *renderFlags) register(fs *flag.FlagSet, formats []string) {
+	fs.StringVar(&f.format, "format", formats[0], "output `format`: "+strings.Join(formats, ", "))
+	fs.StringVar(&f.out, "out", "", "write to a `file`, replacing it atomically, instead of stdout")
+	fs.StringVar(&f.region, "region", awshelpers.CurrentRegion(nil), "`region` of @current in awsService sources")
+	fs.StringVar(&f.opts.Name, "name", render.DefaultName, "`name` prefix of generated objects")
+}
+
+// run resolves the rules of an event file, and writes them in the format.
+func (f *renderFlags) run(fs *flag.FlagSet, formats []string) error {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected an event file")
+	}
+
+	supported := false
+	for _, format := range formats {
+		supported = supported || format == f.format
+	}
+	if !supported {
+		return fmt.Errorf("unsupported format %q, expected one of: %s", f.format, strings.Join(formats, ", "))
+	}
+
+	evt, err := readEvent(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	rules, _, err := evt.resolve(f.region)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := render.Render(f.format, rules, f.opts)
+	if err != nil {
+		return err
+	}
+
+	if f.out == "" {
+		_, err := os.Stdout.Write(rendered)
+		return err
+	}
+
+	return render.WriteFile(f.out, rendered)
+}
+
+// eventUsage returns the usage of a command which reads an event file.
+func eventUsage(fs *flag.FlagSet, description string) func() {
+	return func() {
+		fmt.Fprintf(fs.Output(), "Usage: dsg %s [flags] EVENT\n", fs.Name())
+		fmt.Fprintln(fs.Output(), "EVENT is a dynamic-firewall, dns-firewall or aws-api-egress event file, or - for stdin. "+description)
+		fs.PrintDefaults()
+	}
+}
+
+func runRender(args []string) error {
+	var selector string
+
+	f := &renderFlags{}
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	f.register(fs, renderFormats)
+	fs.BoolVar(&f.opts.Forward, "forward", false, "filter forwarded traffic, as on a NAT instance")
+	fs.StringVar(&f.opts.Namespace, "namespace", "", "`namespace` of network policies")
+	fs.StringVar(&selector, "selector", "", "comma separated key=value `labels` of the pods which network policies apply to")
+	fs.Usage = eventUsage(fs, "Its rules are resolved and rendered as host firewall configuration or network policies.")
+	fs.Parse(args)
+
+	var err error
+	if f.opts.PodSelector, err = labels(selector); err != nil {
+		return err
+	}
+
+	return f.run(fs, renderFormats)
+}
+
+func runExport(args []string) error {
+	f := &renderFlags{}
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	f.register(fs, exportFormats)
+	fs.StringVar(&f.opts.SecurityGroup, "security-group", "", "`ID` of the security group; a variable or parameter is declared if empty")
+	fs.Usage = eventUsage(fs, "Its rules are resolved and exported as Terraform or CloudFormation resources.")
+	fs.Parse(args)
+
+	return f.run(fs, exportFormats)
+}
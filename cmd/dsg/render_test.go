@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResolve checks that events are resolved by the same functions as their
+// lambdas call.
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+
+		// lambda resolves the event with the function its lambda calls.
+		lambda func(evt *event) ([]rule.Rule, error)
+	}{
+		{
+			name:  "DynamicFirewall",
+			event: `{"sources": [{"type": "static", "name": "office", "cidrs": ["198.51.100.0/24"], "ports": [22]}]}`,
+			lambda: func(evt *event) ([]rule.Rule, error) {
+				resolver := &source.Resolver{CurrentRegion: "us-west-2"}
+				return resolver.Resolve(evt.Sources)
+			},
+		},
+		{
+			name: "DNSFirewall",
+			event: `{"rules": [
+				{"name": "api.example.com", "port": 443, "protocol": "tcp", "egress": true, "cidrs": ["203.0.113.10/32", "2001:db8::1/128"]},
+				{"name": "ping.example.com", "port": -1, "protocol": "icmp", "egress": true, "cidrs": ["198.51.100.0/24"]}
+			]}`,
+			lambda: func(evt *event) ([]rule.Rule, error) {
+				return rule.ResolveRules(evt.Rules)
+			},
+		},
+		{
+			name:  "AWSAPIEgress",
+			event: `{"services": ["S3"], "regions": ["@current"], "url": "file://../../pkg/awsips/testdata/ip-ranges.json"}`,
+			lambda: func(evt *event) ([]rule.Rule, error) {
+				rules, _, err := evt.AWSServices.Resolve(evt.Source(), "us-west-2")
+				return rules, err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			evt := &event{}
+			assert.NoError(t, json.Unmarshal([]byte(test.event), evt))
+
+			expect, err := test.lambda(evt)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, expect)
+
+			rules, _, err := evt.resolve("us-west-2")
+			assert.NoError(t, err)
+			assert.Equal(t, expect, rules)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
+)
+
+var (
+	sess    = session.New()
+	clients = rule.NewClients(sess)
+)
+
+// Event is passed into the lambda function at runtime.
+type Event struct {
+	// Sources are the DNS names, AWS services and static CIDRs to whitelist.
+	Sources []source.Source `json:"sources"`
+
+	// IPRanges configures the IP ranges file for awsService sources.
+	IPRanges awsips.Config `json:"ipRanges"`
+
+	// Targets are the security groups, and other resources such as prefix
+	// lists, to apply them to.
+	rule.Targets
+
+	// Options configure how rules are applied to the security groups.
+	rule.Options
+}
+
+func main() {
+	lambda.Start(lambdaHandler)
+}
+
+func lambdaHandler(_ context.Context, evt Event) (string, error) {
+	if err := evt.Validate(); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+	if err := evt.ValidateDomainTargets(source.HasDNS(evt.Sources), evt.Options); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+
+	resolver := &source.Resolver{
+		IPRanges:      evt.IPRanges,
+		CurrentRegion: awshelpers.CurrentRegion(sess),
+	}
+
+	// Every source is resolved before any security group is changed, so
+	// Cleanup never removes rules belonging to another source.
+	rules, err := resolver.Resolve(evt.Sources)
+	if err != nil {
+		log.Printf("Failed to resolve sources: %+v", err)
+		return awshelpers.LambdaOutput(err)
+	}
+
+	opts := resolver.Options(evt.Options)
+
+	errs := make([]error, 0)
+	for _, target := range evt.Build(clients) {
+		if err := target.Apply(rules, opts); err != nil {
+			log.Printf("Failed to apply rules to %s: %+v", target, err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return awshelpers.LambdaOutput(errs[0])
+	}
+
+	return awshelpers.LambdaOutput(nil)
+}
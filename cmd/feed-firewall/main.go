@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/feeds"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+var clients = rule.NewClients(session.New())
+
+// Event is passed into the lambda function at runtime.
+type Event struct {
+	// Feeds are the provider feeds to whitelist.
+	Feeds []feeds.Selection `json:"feeds"`
+
+	// Targets are the security groups, and other resources such as prefix
+	// lists, to apply them to.
+	rule.Targets
+
+	// Options configure how rules are applied to the security groups.
+	rule.Options
+}
+
+func main() {
+	lambda.Start(lambdaHandler)
+}
+
+func lambdaHandler(_ context.Context, evt Event) (string, error) {
+	if err := evt.Validate(); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+	// Feeds have no domains.
+	if err := evt.ValidateDomainTargets(false, evt.Options); err != nil {
+		return awshelpers.LambdaOutput(err)
+	}
+
+	rules := make([]rule.Rule, len(evt.Feeds))
+	for i := range evt.Feeds {
+		var err error
+		if rules[i], err = evt.Feeds[i].Rule(); err != nil {
+			log.Printf("Failed to read CIDRs for %s: %+v", evt.Feeds[i].Name(), err)
+			return awshelpers.LambdaOutput(err)
+		}
+	}
+
+	errs := make([]error, 0)
+	for _, target := range evt.Build(clients) {
+		if err := target.Apply(rules, evt.Options); err != nil {
+			log.Printf("Failed to apply rules to %s: %+v", target, err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return awshelpers.LambdaOutput(errs[0])
+	}
+
+	return awshelpers.LambdaOutput(nil)
+}
@@ -1,11 +1,21 @@
 module github.com/jniedrauer/dynamic-security-groups
 
+go 1.27.1
+
 require (
 	github.com/aws/aws-lambda-go v1.8.2
-	github.com/aws/aws-sdk-go v1.16.33
+	github.com/aws/aws-sdk-go v1.55.5
 	github.com/golang/lint v0.0.0-20181217174547-8f45f776aaf1
 	github.com/jstemmer/go-junit-report v0.0.0-20190106144839-af01ea7f8024
 	github.com/stretchr/testify v1.3.0
+	gopkg.in/yaml.v2 v2.2.8
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.1.0 // indirect
 	golang.org/x/lint v0.0.0-20181217174547-8f45f776aaf1 // indirect
 	golang.org/x/net v0.0.0-20190213061140-3a22650c66bd // indirect
 	golang.org/x/text v0.3.0 // indirect
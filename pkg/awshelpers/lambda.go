@@ -14,3 +14,13 @@ func LambdaOutput(err error) (string, error) {
 
 	return success, nil
 }
+
+// LambdaOutputDetail standardizes lambda output format, with additional
+// detail on success.
+func LambdaOutputDetail(detail string, err error) (string, error) {
+	if err != nil {
+		return failure, err
+	}
+
+	return success + ": " + detail, nil
+}
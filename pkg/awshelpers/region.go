@@ -0,0 +1,26 @@
+package awshelpers
+
+import (
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// CurrentRegion returns the region the caller is running in. The lambda
+// runtime sets AWS_REGION, and AWS_DEFAULT_REGION is checked for other
+// environments. The session configuration is used as a fallback. An empty
+// string is returned if the region cannot be determined.
+func CurrentRegion(sess *session.Session) string {
+	for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if region := os.Getenv(env); region != "" {
+			return region
+		}
+	}
+
+	if sess == nil {
+		return ""
+	}
+
+	return aws.StringValue(sess.Config.Region)
+}
@@ -1,23 +1,41 @@
 package awsips
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/selector"
 )
 
 // IPRangesFile is a file published by Amazon with a list of their public
 // CIDRs. This file may change periodically.
 const IPRangesFile = "https://ip-ranges.amazonaws.com/ip-ranges.json"
 
-// blacklistedServices is used to remove services from the generated rules. The
-// EC2 service is specifically blacklisted because it contains the IP space for
+// RegionGlobal is the region of prefixes which are not tied to a single
+// region, such as CloudFront edge locations.
+const RegionGlobal = "GLOBAL"
+
+// ServiceEC2 is the service containing the IP space for public (ie. customer
+// managed) EC2 IP addresses.
+const ServiceEC2 = "EC2"
+
+// defaultExcludedServices is used to remove services from the generated rules.
+// The EC2 service is excluded by default because it contains the IP space for
 // public (ie. customer managed) EC2 IP addresses.
-var blacklistedServices = []string{
-	"EC2",
+var defaultExcludedServices = []string{
+	ServiceEC2,
 }
 
 // IPRanges is the deserialized IPRangesFile.
@@ -30,9 +48,20 @@ type IPRanges struct {
 
 // Prefix is a single AWS service CIDR.
 type Prefix struct {
-	IPPrefix string `json:"ip_prefix"`
-	Region   string `json:"region"`
-	Service  string `json:"service"`
+	IPPrefix           string `json:"ip_prefix"`
+	Region             string `json:"region"`
+	Service            string `json:"service"`
+	NetworkBorderGroup string `json:"network_border_group"`
+}
+
+// BorderGroup returns the network border group of the prefix. Older versions
+// of the IP ranges file do not include this field, in which case the region
+// is the border group.
+func (p Prefix) BorderGroup() string {
+	if p.NetworkBorderGroup == "" {
+		return p.Region
+	}
+	return p.NetworkBorderGroup
 }
 
 // IPRangesGetter deserializes a remote IP rages file.
@@ -40,83 +69,389 @@ type IPRangesGetter struct {
 	// url is the URL to download the IP ranges file from.
 	url string
 
-	// regions are the regions to get IP addresses in.
-	regions []string
+	// regions are the selectors for regions to get IP addresses in.
+	regions []selector.Selector
+
+	// includeGlobal includes prefixes in the GLOBAL region.
+	includeGlobal bool
+
+	// borderGroups are the selectors for network border groups to get IP
+	// addresses in. All border groups are included if this is empty.
+	borderGroups []selector.Selector
+
+	// configErr is returned by GetService if the options are invalid.
+	configErr error
+
+	// excludedServices are removed from the results of GetService.
+	excludedServices []string
+
+	// configuredExclusions are the services excluded with
+	// WithExcludedServices, which Validate checks. The default exclusions
+	// are not checked, since an IP ranges file need not contain them.
+	configuredExclusions []string
+
+	// allowEC2 stops excluding the EC2 service by default.
+	allowEC2 bool
+
+	// caches store downloaded IP ranges between invocations.
+	caches []Store
+
+	// md5 is the expected hex encoded md5 digest of the IP ranges file.
+	md5 string
+
+	// sha256 is the expected hex encoded sha256 digest of the IP ranges file.
+	sha256 string
+
+	// snapshotFallback uses a cached copy or the compiled-in snapshot if the
+	// IP ranges file cannot be downloaded.
+	snapshotFallback bool
 
 	httpClient *http.Client
+	s3Client   s3iface.S3API
 	ipRanges   *IPRanges
+	getErr     error
 	getOnce    sync.Once
+	unchanged  bool
+	source     string
+}
+
+// Option configures an IPRangesGetter.
+type Option func(*IPRangesGetter)
+
+// WithExcludedServices excludes additional services from the results of
+// GetService. EC2 remains excluded unless WithEC2Allowed is also given.
+func WithExcludedServices(services ...string) Option {
+	return func(g *IPRangesGetter) {
+		for _, svc := range services {
+			if !in(svc, g.excludedServices) {
+				g.excludedServices = append(g.excludedServices, svc)
+			}
+		}
+	}
+}
+
+// WithEC2Allowed stops excluding the EC2 service. This grants access to
+// customer managed EC2 instances, so it should only be used deliberately. It
+// is an error to also exclude EC2 with WithExcludedServices.
+func WithEC2Allowed() Option {
+	return func(g *IPRangesGetter) {
+		g.allowEC2 = true
+	}
+}
+
+// WithIncludeGlobal includes prefixes in the GLOBAL region, which are not
+// matched by region wildcards.
+func WithIncludeGlobal() Option {
+	return func(g *IPRangesGetter) {
+		g.includeGlobal = true
+	}
+}
+
+// WithNetworkBorderGroups restricts results to prefixes in matching network
+// border groups, such as "us-west-2-lax-1" for a Local Zone. Like regions,
+// border groups may be globs or regular expressions enclosed in slashes.
+func WithNetworkBorderGroups(groups ...string) Option {
+	return func(g *IPRangesGetter) {
+		selectors, err := selector.NewList(groups)
+		if err != nil {
+			g.configErr = err
+			return
+		}
+		g.borderGroups = append(g.borderGroups, selectors...)
+	}
+}
+
+// WithCache caches downloaded IP ranges in one or more stores. Entries are
+// loaded from the first store which has one, and saved to every store.
+func WithCache(stores ...Store) Option {
+	return func(g *IPRangesGetter) {
+		g.caches = append(g.caches, stores...)
+	}
+}
+
+// WithMD5 verifies the downloaded IP ranges file against a hex encoded md5
+// digest, such as the one in an AmazonIpSpaceChanged notification.
+func WithMD5(digest string) Option {
+	return func(g *IPRangesGetter) {
+		g.md5 = digest
+	}
+}
+
+// WithSHA256 verifies the downloaded IP ranges file against a hex encoded
+// sha256 digest.
+func WithSHA256(digest string) Option {
+	return func(g *IPRangesGetter) {
+		g.sha256 = digest
+	}
 }
 
-// NewIPRangesGetter creates a new configured IPRangesLoader.
-func NewIPRangesGetter(uri string, regions []string) *IPRangesGetter {
-	return &IPRangesGetter{
-		url:     uri,
-		regions: regions,
+// WithSnapshotFallback uses the last cached copy of the IP ranges file, or
+// the compiled-in snapshot if there is none, when the file cannot be
+// downloaded.
+func WithSnapshotFallback() Option {
+	return func(g *IPRangesGetter) {
+		g.snapshotFallback = true
+	}
+}
+
+// WithS3Client sets the client used to download s3:// URLs.
+func WithS3Client(client s3iface.S3API) Option {
+	return func(g *IPRangesGetter) {
+		g.s3Client = client
+	}
+}
+
+// NewIPRangesGetter creates a new configured IPRangesLoader. The URL may be
+// an http(s)://, file:// or s3://bucket/key URL. Regions may be
+// exact names, globs such as "us-*", or regular expressions enclosed in
+// slashes. The GLOBAL region is only included if it is listed explicitly or
+// WithIncludeGlobal is given.
+func NewIPRangesGetter(uri string, regions []string, opts ...Option) *IPRangesGetter {
+	selectors, err := selector.NewList(regions)
+
+	g := &IPRangesGetter{
+		url:              uri,
+		regions:          selectors,
+		configErr:        err,
+		excludedServices: make([]string, 0),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	g.configuredExclusions = append([]string{}, g.excludedServices...)
+
+	// The default exclusions are applied after every option, so the result
+	// does not depend on the order of the options.
+	switch {
+	case g.allowEC2 && in(ServiceEC2, g.excludedServices):
+		if g.configErr == nil {
+			g.configErr = errors.New("EC2 is both allowed and excluded")
+		}
+	case g.allowEC2:
+		log.Print("WARNING: EC2 is not excluded; rules will include customer managed EC2 IP space")
+	default:
+		WithExcludedServices(defaultExcludedServices...)(g)
+	}
+
+	return g
 }
 
-// Get gets the latest IP ranges.
+// Get gets the latest IP ranges. If a cache is configured, the download is
+// conditional on the file having changed since it was cached.
 func (g *IPRangesGetter) Get() (*IPRanges, error) {
-	var err error
-
 	g.getOnce.Do(func() {
-		log.Printf("GET %s", g.url)
+		g.ipRanges, g.getErr = g.get()
+	})
 
-		var req *http.Request
-		req, err = http.NewRequest(http.MethodGet, g.url, nil)
-		if err != nil {
-			return
-		}
+	return g.ipRanges, g.getErr
+}
+
+// Source returns where the IP ranges returned by Get came from. This is the
+// getter's URL, SourceCache or SourceSnapshot.
+func (g *IPRangesGetter) Source() string {
+	return g.source
+}
+
+// Unchanged returns a boolean for whether the IP ranges returned by Get have
+// the same syncToken as the cached copy.
+func (g *IPRangesGetter) Unchanged() bool {
+	return g.unchanged
+}
+
+// get gets the IP ranges file. If it cannot be downloaded and the snapshot
+// fallback is enabled, the last cached copy or the compiled-in snapshot is
+// used instead.
+func (g *IPRangesGetter) get() (*IPRanges, error) {
+	cached := g.loadCache()
+
+	var last *IPRanges
+	if cached != nil {
+		last = cached.Ranges
+	}
+
+	// A cached copy with a different digest is known to be out of date.
+	if cached != nil && g.md5 != "" && !strings.EqualFold(g.md5, cached.MD5) {
+		cached = nil
+	}
+
+	result, err := g.download(cached, last)
+	if err == nil || !g.snapshotFallback {
+		return result, err
+	}
+
+	log.Printf("Failed to get %s: %+v", g.url, err)
+
+	if last != nil {
+		log.Printf("Using cached IP ranges with syncToken %s", last.SyncToken)
+		g.source = SourceCache
+		return last, nil
+	}
+
+	log.Print("Using compiled-in IP ranges snapshot")
+	g.source = SourceSnapshot
+	return parse([]byte(snapshot))
+}
+
+// download gets the IP ranges file from the getter's URL. The download is
+// conditional on the cached copy having changed, if there is one.
+func (g *IPRangesGetter) download(cached *CacheEntry, last *IPRanges) (*IPRanges, error) {
+	log.Printf("GET %s", g.url)
+
+	f, err := g.fetch(cached)
+	if err != nil {
+		return nil, err
+	}
 
-		var res *http.Response
-		res, err = g.httpClient.Do(req)
+	if f.notModified && cached != nil {
+		log.Printf("Not modified since syncToken %s", cached.Ranges.SyncToken)
+		g.unchanged = true
+		g.source = g.url
+		g.saveCache(cached)
+		return cached.Ranges, nil
+	}
+
+	result, err := g.decode(f.body, last)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := md5.Sum(f.body)
+	digest := hex.EncodeToString(sum[:])
+
+	g.unchanged = cached != nil && cached.Ranges.SyncToken == result.SyncToken
+	g.source = g.url
+	g.saveCache(&CacheEntry{
+		ETag:         f.etag,
+		LastModified: f.lastModified,
+		MD5:          digest,
+		Ranges:       result,
+	})
+
+	return result, nil
+}
+
+// decode verifies and deserializes an IP ranges file. The syncToken must not
+// be older than the syncToken of the last known IP ranges, if there are any.
+func (g *IPRangesGetter) decode(body []byte, last *IPRanges) (*IPRanges, error) {
+	if err := checkDigest("md5", md5.New(), g.md5, body); err != nil {
+		return nil, err
+	}
+
+	if err := checkDigest("sha256", sha256.New(), g.sha256, body); err != nil {
+		return nil, err
+	}
+
+	result, err := parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if last != nil {
+		older, err := olderSyncToken(result.SyncToken, last.SyncToken)
 		if err != nil {
-			return
+			return nil, err
 		}
-		defer res.Body.Close()
 
-		if res.StatusCode != http.StatusOK {
-			err = fmt.Errorf("Got status: %d", res.StatusCode)
-			return
+		if older {
+			return nil, fmt.Errorf("syncToken %s is older than last known syncToken %s", result.SyncToken, last.SyncToken)
 		}
+	}
 
-		result := &IPRanges{}
-		if err = json.NewDecoder(res.Body).Decode(result); err != nil {
-			return
-		}
+	return result, nil
+}
 
-		g.ipRanges = result
-	})
+// parse deserializes an IP ranges file.
+func parse(body []byte) (*IPRanges, error) {
+	result := &IPRanges{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Prefixes) == 0 {
+		return nil, errors.New("IP ranges file contains no prefixes")
+	}
+
+	return result, nil
+}
+
+// checkDigest compares the hex encoded digest of body to an expected digest.
+// Nothing is checked if the expected digest is empty.
+func checkDigest(name string, h hash.Hash, expect string, body []byte) error {
+	if expect == "" {
+		return nil
+	}
+
+	h.Write(body)
+	digest := hex.EncodeToString(h.Sum(nil))
+
+	if !strings.EqualFold(expect, digest) {
+		return fmt.Errorf("%s mismatch: expected %s, got %s", name, expect, digest)
+	}
+
+	return nil
+}
+
+// olderSyncToken returns a boolean for whether a syncToken is older than
+// another. SyncTokens are unix timestamps.
+func olderSyncToken(token, than string) (bool, error) {
+	a, err := strconv.ParseInt(token, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid syncToken %q", token)
+	}
 
-	return g.ipRanges, err
+	b, err := strconv.ParseInt(than, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid syncToken %q", than)
+	}
+
+	return a < b, nil
 }
 
-// GetService gets a list of CIDRs for a given service. The EC2 service is
-// explicitly filtered from the results, since it contains third party EC2
-// instance IPs.
+// GetService gets a list of CIDRs for a given service. The address space of
+// excluded services in every region is subtracted from the results, splitting
+// CIDRs where necessary. By default only the EC2 service is excluded, since it
+// contains third party EC2 instance IPs.
 func (g *IPRangesGetter) GetService(service string) ([]string, error) {
+	if g.configErr != nil {
+		return nil, g.configErr
+	}
+
 	unfiltered, err := g.getUnfilteredService(service)
 	if err != nil {
 		return nil, err
 	}
 
-	blacklist := make([]string, 0)
-	for _, svc := range blacklistedServices {
-		cidrs, err := g.getUnfilteredService(svc)
+	excluded := make([]ipRange, 0)
+	for _, svc := range g.excludedServices {
+		prefixes, err := g.servicePrefixes(svc)
 		if err != nil {
 			return nil, err
 		}
-		blacklist = append(blacklist, cidrs...)
+
+		for _, prefix := range prefixes {
+			r, err := parseRange(prefix.IPPrefix)
+			if err != nil {
+				return nil, err
+			}
+			excluded = append(excluded, r)
+		}
 	}
+	excluded = mergeRanges(excluded)
 
-	filtered := unfiltered[:0]
-	for _, val := range unfiltered {
-		if !in(val, blacklist) {
-			filtered = append(filtered, val)
+	filtered := make([]string, 0, len(unfiltered))
+	for _, cidr := range unfiltered {
+		r, err := parseRange(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, remaining := range r.subtract(excluded) {
+			filtered = append(filtered, remaining.cidrs()...)
 		}
 	}
 
@@ -125,18 +460,14 @@ func (g *IPRangesGetter) GetService(service string) ([]string, error) {
 
 // getUnfilteredService returns a list of CIDRs for a given service.
 func (g *IPRangesGetter) getUnfilteredService(service string) ([]string, error) {
-	ranges, err := g.Get()
+	prefixes, err := g.servicePrefixes(service)
 	if err != nil {
 		return nil, err
 	}
 
 	cidrs := make([]string, 0)
-	for _, prefix := range ranges.Prefixes {
-		if prefix.Service != service {
-			continue
-		}
-
-		if !in(prefix.Region, g.regions) {
+	for _, prefix := range prefixes {
+		if !g.selected(prefix) {
 			continue
 		}
 
@@ -146,6 +477,47 @@ func (g *IPRangesGetter) getUnfilteredService(service string) ([]string, error)
 	return cidrs, nil
 }
 
+// selected returns a boolean for whether a prefix is in a selected region and
+// network border group.
+func (g *IPRangesGetter) selected(prefix Prefix) bool {
+	if prefix.Region == RegionGlobal {
+		if g.includeGlobal {
+			return true
+		}
+
+		// Wildcards never match the GLOBAL region.
+		for _, s := range g.regions {
+			if s.Literal() && s.Pattern() == RegionGlobal {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !selector.MatchAny(g.regions, prefix.Region) {
+		return false
+	}
+
+	return len(g.borderGroups) == 0 || selector.MatchAny(g.borderGroups, prefix.BorderGroup())
+}
+
+// servicePrefixes returns the prefixes for a given service in every region.
+func (g *IPRangesGetter) servicePrefixes(service string) ([]Prefix, error) {
+	ranges, err := g.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]Prefix, 0)
+	for _, prefix := range ranges.Prefixes {
+		if prefix.Service == service {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return prefixes, nil
+}
+
 // in returns a boolean for string in slice.
 func in(match string, search []string) bool {
 	for _, val := range search {
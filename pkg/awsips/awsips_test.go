@@ -11,7 +11,9 @@ import (
 func TestIPRangesGetter(t *testing.T) {
 	tests := []struct {
 		name    string
+		file    string
 		regions []string
+		opts    []Option
 		service string
 		status  int
 		expect  []string
@@ -78,9 +80,239 @@ func TestIPRangesGetter(t *testing.T) {
 				"52.93.12.13/32",
 				"176.32.125.0/25",
 				"52.94.10.0/24",
-				"34.223.24.0/22",
 			},
 		},
+		{
+			name:    "SubtractContainedEC2Ranges",
+			file:    "testdata/ip-ranges-overlap.json",
+			status:  http.StatusOK,
+			regions: []string{"us-west-2"},
+			service: "AMAZON",
+			expect: []string{
+				"54.200.0.0/20",
+				"54.200.32.0/19",
+				"54.200.64.0/18",
+				"54.200.129.0/24",
+				"54.200.130.0/23",
+				"54.200.132.0/22",
+				"54.200.136.0/21",
+				"54.200.144.0/20",
+				"54.200.160.0/19",
+				"54.200.192.0/18",
+				"52.92.32.0/22",
+			},
+		},
+		{
+			name:    "SubtractIdenticalRange",
+			file:    "testdata/ip-ranges-overlap.json",
+			status:  http.StatusOK,
+			regions: []string{"us-west-2"},
+			opts:    []Option{WithExcludedServices("S3")},
+			service: "AMAZON",
+			expect: []string{
+				"54.200.0.0/20",
+				"54.200.32.0/19",
+				"54.200.64.0/18",
+				"54.200.129.0/24",
+				"54.200.130.0/23",
+				"54.200.132.0/22",
+				"54.200.136.0/21",
+				"54.200.144.0/20",
+				"54.200.160.0/19",
+				"54.200.192.0/18",
+			},
+		},
+		{
+			name:    "ExcludeCloudfrontGlobal",
+			status:  http.StatusOK,
+			regions: []string{"GLOBAL"},
+			opts:    []Option{WithExcludedServices("CLOUDFRONT")},
+			service: "AMAZON",
+			expect: []string{
+				"13.248.128.0/17",
+				"76.223.0.0/17",
+				"75.2.0.0/17",
+				"99.83.128.0/17",
+				"99.82.156.0/22",
+				"52.95.110.0/24",
+			},
+		},
+		{
+			name:    "EC2ExcludedByDefault",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			service: "EC2",
+			expect:  []string{},
+		},
+		{
+			name:    "EC2Allowed",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithEC2Allowed()},
+			service: "EC2",
+			expect: []string{
+				"157.175.0.0/16",
+				"52.95.228.0/24",
+			},
+		},
+		{
+			name:    "EC2AllowedAndExcluded",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithEC2Allowed(), WithExcludedServices("EC2")},
+			service: "EC2",
+			err:     true,
+		},
+		{
+			name:    "EC2ExcludedAndAllowed",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithExcludedServices("EC2"), WithEC2Allowed()},
+			service: "EC2",
+			err:     true,
+		},
+		{
+			name:    "EC2AllowedBeforeExclusions",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithEC2Allowed(), WithExcludedServices("S3")},
+			service: "EC2",
+			expect: []string{
+				"157.175.0.0/16",
+				"52.95.228.0/24",
+			},
+		},
+		{
+			name:    "RegionGlob",
+			file:    "testdata/ip-ranges-border-groups.json",
+			status:  http.StatusOK,
+			regions: []string{"us-*"},
+			service: "S3",
+			expect: []string{
+				"52.94.76.0/22",
+				"15.253.0.0/16",
+				"15.181.232.0/21",
+				"52.216.0.0/15",
+				"52.219.80.0/20",
+			},
+		},
+		{
+			name:    "RegionRegexp",
+			file:    "testdata/ip-ranges-border-groups.json",
+			status:  http.StatusOK,
+			regions: []string{"/^(us-east-[0-9]|eu-.*)$/"},
+			service: "S3",
+			expect: []string{
+				"52.216.0.0/15",
+				"52.219.80.0/20",
+				"52.218.0.0/17",
+			},
+		},
+		{
+			name:    "InvalidRegionRegexp",
+			file:    "testdata/ip-ranges-border-groups.json",
+			status:  http.StatusOK,
+			regions: []string{"/us-(/"},
+			service: "S3",
+			err:     true,
+		},
+		{
+			name:    "GlobalExplicitRegion",
+			file:    "testdata/ip-ranges-border-groups.json",
+			status:  http.StatusOK,
+			regions: []string{"GLOBAL", "eu-west-1"},
+			service: "S3",
+			expect: []string{
+				"52.218.0.0/17",
+				"52.95.110.0/24",
+			},
+		},
+		{
+			name:    "GlobalIncluded",
+			file:    "testdata/ip-ranges-border-groups.json",
+			status:  http.StatusOK,
+			regions: []string{"eu-*"},
+			opts:    []Option{WithIncludeGlobal()},
+			service: "S3",
+			expect: []string{
+				"52.218.0.0/17",
+				"52.95.110.0/24",
+			},
+		},
+		{
+			name:    "NetworkBorderGroups",
+			file:    "testdata/ip-ranges-border-groups.json",
+			status:  http.StatusOK,
+			regions: []string{"*"},
+			opts:    []Option{WithNetworkBorderGroups("us-west-2", "*-lax-*")},
+			service: "S3",
+			expect: []string{
+				"52.94.76.0/22",
+				"15.253.0.0/16",
+			},
+		},
+		{
+			name:    "NetworkBorderGroupsLegacyFile",
+			status:  http.StatusOK,
+			regions: []string{"*"},
+			opts:    []Option{WithNetworkBorderGroups("me-south-1")},
+			service: "AMAZON",
+			expect: []string{
+				"13.248.106.0/24",
+				"52.119.249.0/24",
+				"52.95.174.0/24",
+				"99.82.128.0/20",
+				"52.95.172.0/23",
+				"99.82.152.0/22",
+				"99.82.144.0/21",
+			},
+		},
+		{
+			name:    "MD5Match",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithMD5("2182BFF9048FE44D4C1D9B37903FC632")},
+			service: "S3",
+			expect: []string{
+				"52.95.174.0/24",
+				"52.95.172.0/23",
+			},
+		},
+		{
+			name:    "MD5Mismatch",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithMD5("d41d8cd98f00b204e9800998ecf8427e")},
+			service: "S3",
+			err:     true,
+		},
+		{
+			name:    "SHA256Match",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithSHA256("d4605875b4e81dc88d334c96685bc4483173cfdf8eaa393e65701df59b42f992")},
+			service: "S3",
+			expect: []string{
+				"52.95.174.0/24",
+				"52.95.172.0/23",
+			},
+		},
+		{
+			name:    "SHA256Mismatch",
+			status:  http.StatusOK,
+			regions: []string{"me-south-1"},
+			opts:    []Option{WithSHA256("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")},
+			service: "S3",
+			err:     true,
+		},
+		{
+			name:    "NoPrefixes",
+			file:    "testdata/ip-ranges-empty.json",
+			status:  http.StatusOK,
+			regions: []string{"us-east-1"},
+			service: "S3",
+			err:     true,
+		},
 		{
 			name:    "ServerError",
 			status:  http.StatusBadGateway,
@@ -92,9 +324,13 @@ func TestIPRangesGetter(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			if test.file == "" {
+				test.file = "testdata/ip-ranges.json"
+			}
+
 			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				if test.status == http.StatusOK {
-					http.ServeFile(w, r, "testdata/ip-ranges.json")
+					http.ServeFile(w, r, test.file)
 					return
 				}
 
@@ -102,7 +338,7 @@ func TestIPRangesGetter(t *testing.T) {
 			}))
 			defer ts.Close()
 
-			getter := NewIPRangesGetter(ts.URL, test.regions)
+			getter := NewIPRangesGetter(ts.URL, test.regions, test.opts...)
 			result, err := getter.GetService(test.service)
 
 			if test.err {
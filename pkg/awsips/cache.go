@@ -0,0 +1,156 @@
+package awsips
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultCache is a package level cache, which survives between invocations
+// in a warm lambda container.
+var DefaultCache = NewMemoryStore()
+
+// CacheEntry is a cached copy of an IP ranges file.
+type CacheEntry struct {
+	// ETag is the entity tag of the downloaded file.
+	ETag string `json:"etag"`
+
+	// LastModified is the Last-Modified header of the downloaded file.
+	LastModified string `json:"lastModified"`
+
+	// MD5 is the hex encoded md5 digest of the downloaded file.
+	MD5 string `json:"md5"`
+
+	// Ranges are the deserialized IP ranges.
+	Ranges *IPRanges `json:"ranges"`
+}
+
+// Store persists cache entries.
+type Store interface {
+	// Load returns the entry for a URL, or nil if there is none.
+	Load(url string) (*CacheEntry, error)
+
+	// Save stores the entry for a URL.
+	Save(url string, entry *CacheEntry) error
+}
+
+// MemoryStore stores cache entries in memory.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*CacheEntry
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*CacheEntry),
+	}
+}
+
+// Load returns the entry for a URL.
+func (s *MemoryStore) Load(url string) (*CacheEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.entries[url], nil
+}
+
+// Save stores the entry for a URL.
+func (s *MemoryStore) Save(url string, entry *CacheEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[url] = entry
+	return nil
+}
+
+// FileStore stores cache entries as JSON files in a directory, such as /tmp
+// or a mounted file system.
+type FileStore struct {
+	// Dir is the directory to store entries in.
+	Dir string
+}
+
+// Load returns the entry for a URL.
+func (s *FileStore) Load(url string) (*CacheEntry, error) {
+	data, err := ioutil.ReadFile(s.path(url))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &CacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Save stores the entry for a URL. The file is replaced atomically, so a
+// concurrent Load never sees a partial entry.
+func (s *FileStore) Save(url string, entry *CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(s.Dir, ".ip-ranges-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(url))
+}
+
+// path returns the file name for a URL.
+func (s *FileStore) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// loadCache returns the first cache entry found for the getter's URL.
+func (g *IPRangesGetter) loadCache() *CacheEntry {
+	for _, store := range g.caches {
+		entry, err := store.Load(g.url)
+		if err != nil {
+			log.Printf("Failed to load cached IP ranges: %+v", err)
+			continue
+		}
+
+		if entry != nil && entry.Ranges != nil {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// saveCache saves a cache entry for the getter's URL to every store.
+func (g *IPRangesGetter) saveCache(entry *CacheEntry) {
+	for _, store := range g.caches {
+		if err := store.Save(g.url, entry); err != nil {
+			log.Printf("Failed to cache IP ranges: %+v", err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package awsips
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awsips")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name   string
+		stores func() []Store
+	}{
+		{
+			name: "MemoryStore",
+			stores: func() []Store {
+				return []Store{NewMemoryStore()}
+			},
+		},
+		{
+			name: "FileStore",
+			stores: func() []Store {
+				return []Store{&FileStore{Dir: dir}}
+			},
+		},
+		{
+			name: "MemoryAndFileStore",
+			stores: func() []Store {
+				return []Store{NewMemoryStore(), &FileStore{Dir: dir + "/tiered"}}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			const etag = `"0123456789abcdef"`
+
+			downloads := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+
+				downloads++
+				w.Header().Set("ETag", etag)
+				http.ServeFile(w, r, "testdata/ip-ranges.json")
+			}))
+			defer ts.Close()
+
+			stores := test.stores()
+
+			first := NewIPRangesGetter(ts.URL, []string{"us-east-1"}, WithCache(stores...))
+			expect, err := first.GetService("S3")
+			assert.NoError(t, err)
+			assert.False(t, first.Unchanged())
+
+			second := NewIPRangesGetter(ts.URL, []string{"us-east-1"}, WithCache(stores...))
+			result, err := second.GetService("S3")
+			assert.NoError(t, err)
+			assert.True(t, second.Unchanged())
+
+			assert.Equal(t, expect, result)
+			assert.Equal(t, 1, downloads)
+		})
+	}
+}
+
+func TestCacheSameSyncToken(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/ip-ranges.json")
+	assert.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Conditional requests are not supported by this server.
+		w.Write(data)
+	}))
+	defer ts.Close()
+
+	store := NewMemoryStore()
+
+	first := NewIPRangesGetter(ts.URL, nil, WithCache(store))
+	_, err = first.Get()
+	assert.NoError(t, err)
+	assert.False(t, first.Unchanged())
+
+	second := NewIPRangesGetter(ts.URL, nil, WithCache(store))
+	_, err = second.Get()
+	assert.NoError(t, err)
+	assert.True(t, second.Unchanged())
+}
+
+func TestCacheOlderSyncToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/ip-ranges.json")
+	}))
+	defer ts.Close()
+
+	store := NewMemoryStore()
+	assert.NoError(t, store.Save(ts.URL, &CacheEntry{
+		Ranges: &IPRanges{SyncToken: "1549989080"},
+	}))
+
+	getter := NewIPRangesGetter(ts.URL, nil, WithCache(store))
+	_, err := getter.Get()
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package awsips
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net"
+	"sort"
+)
+
+// ipRange is an inclusive range of IPv4 addresses.
+type ipRange struct {
+	first uint64
+	last  uint64
+}
+
+// parseRange parses an IPv4 CIDR into an address range.
+func parseRange(cidr string) (ipRange, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return ipRange{}, err
+	}
+
+	ip := ipnet.IP.To4()
+	if ip == nil {
+		return ipRange{}, fmt.Errorf("not an IPv4 CIDR: %s", cidr)
+	}
+
+	ones, size := ipnet.Mask.Size()
+	first := uint64(binary.BigEndian.Uint32(ip))
+
+	return ipRange{
+		first: first,
+		last:  first + (1 << uint(size-ones)) - 1,
+	}, nil
+}
+
+// mergeRanges sorts ranges and merges the ones that overlap or are adjacent.
+func mergeRanges(ranges []ipRange) []ipRange {
+	sorted := make([]ipRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].first < sorted[j].first
+	})
+
+	merged := make([]ipRange, 0, len(sorted))
+	for _, r := range sorted {
+		if n := len(merged); n > 0 && r.first <= merged[n-1].last+1 {
+			if r.last > merged[n-1].last {
+				merged[n-1].last = r.last
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// subtract removes excluded address ranges from r. The excluded ranges must be
+// sorted and merged.
+func (r ipRange) subtract(excluded []ipRange) []ipRange {
+	result := make([]ipRange, 0)
+
+	next := r.first
+	for _, e := range excluded {
+		if e.last < next {
+			continue
+		}
+		if e.first > r.last {
+			break
+		}
+
+		if e.first > next {
+			result = append(result, ipRange{first: next, last: e.first - 1})
+		}
+		next = e.last + 1
+
+		if next > r.last {
+			return result
+		}
+	}
+
+	return append(result, ipRange{first: next, last: r.last})
+}
+
+// cidrs returns the smallest list of CIDR blocks covering the range.
+func (r ipRange) cidrs() []string {
+	cidrs := make([]string, 0)
+
+	for first := r.first; first <= r.last; {
+		// The largest block starting at first is limited by its alignment.
+		hostBits := 32
+		if first != 0 {
+			hostBits = bits.TrailingZeros64(first)
+			if hostBits > 32 {
+				hostBits = 32
+			}
+		}
+
+		// It is also limited by the end of the range.
+		for first+(1<<uint(hostBits))-1 > r.last {
+			hostBits--
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, uint32(first))
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip, 32-hostBits))
+
+		first += 1 << uint(hostBits)
+	}
+
+	return cidrs
+}
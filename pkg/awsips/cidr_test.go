@@ -0,0 +1,105 @@
+package awsips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidr     string
+		excluded []string
+
+		expect []string
+	}{
+		{
+			name:   "NothingExcluded",
+			cidr:   "10.0.0.0/16",
+			expect: []string{"10.0.0.0/16"},
+		},
+		{
+			name:     "Disjoint",
+			cidr:     "10.0.0.0/16",
+			excluded: []string{"10.1.0.0/16", "192.168.0.0/24"},
+			expect:   []string{"10.0.0.0/16"},
+		},
+		{
+			name:     "Identical",
+			cidr:     "10.0.0.0/16",
+			excluded: []string{"10.0.0.0/16"},
+			expect:   []string{},
+		},
+		{
+			name:     "ContainedBySupernet",
+			cidr:     "10.0.4.0/24",
+			excluded: []string{"10.0.0.0/16"},
+			expect:   []string{},
+		},
+		{
+			name:     "FirstHalf",
+			cidr:     "10.0.0.0/16",
+			excluded: []string{"10.0.0.0/17"},
+			expect:   []string{"10.0.128.0/17"},
+		},
+		{
+			name:     "SingleAddress",
+			cidr:     "10.0.0.0/29",
+			excluded: []string{"10.0.0.3/32"},
+			expect: []string{
+				"10.0.0.0/31",
+				"10.0.0.2/32",
+				"10.0.0.4/30",
+			},
+		},
+		{
+			name:     "MultipleOverlappingExclusions",
+			cidr:     "10.0.0.0/24",
+			excluded: []string{"10.0.0.64/26", "10.0.0.0/25", "10.0.0.192/27"},
+			expect: []string{
+				"10.0.0.128/26",
+				"10.0.0.224/27",
+			},
+		},
+		{
+			name:     "EntireAddressSpace",
+			cidr:     "0.0.0.0/0",
+			excluded: []string{"128.0.0.0/1"},
+			expect:   []string{"0.0.0.0/1"},
+		},
+		{
+			name:     "EndOfAddressSpace",
+			cidr:     "255.255.255.0/24",
+			excluded: []string{"255.255.255.0/25"},
+			expect:   []string{"255.255.255.128/25"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := parseRange(test.cidr)
+			assert.NoError(t, err)
+
+			excluded := make([]ipRange, len(test.excluded))
+			for i := range test.excluded {
+				excluded[i], err = parseRange(test.excluded[i])
+				assert.NoError(t, err)
+			}
+
+			result := make([]string, 0)
+			for _, remaining := range r.subtract(mergeRanges(excluded)) {
+				result = append(result, remaining.cidrs()...)
+			}
+
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func TestParseRangeError(t *testing.T) {
+	for _, cidr := range []string{"", "10.0.0.0", "2600:1f14::/35"} {
+		_, err := parseRange(cidr)
+		assert.Error(t, err, cidr)
+	}
+}
@@ -0,0 +1,83 @@
+package awsips
+
+// Config is the JSON configuration of an IPRangesGetter, as embedded in
+// lambda events.
+type Config struct {
+	// URL is the IP ranges file to use. It may be an http(s)://, file:// or
+	// s3://bucket/key URL, and defaults to the file published by Amazon.
+	URL string `json:"url,omitempty"`
+
+	// PairedRegions maps regions to additional regions to whitelist, such
+	// as S3 cross-region replication destinations.
+	PairedRegions map[string][]string `json:"pairedRegions,omitempty"`
+
+	// IncludeGlobal whitelists the GLOBAL region, which is not matched by
+	// region wildcards.
+	IncludeGlobal bool `json:"includeGlobal,omitempty"`
+
+	// NetworkBorderGroups restricts the whitelist to network border groups,
+	// such as Local Zones. All border groups are whitelisted if empty.
+	NetworkBorderGroups []string `json:"networkBorderGroups,omitempty"`
+
+	// ExcludeServices are AWS services whose CIDRs are removed from the
+	// whitelisted services, in addition to EC2.
+	ExcludeServices []string `json:"excludeServices,omitempty"`
+
+	// AllowEC2 stops excluding the EC2 service. The EC2 service contains
+	// customer managed IP space, so this must be explicitly enabled. It is
+	// an error to also list EC2 in ExcludeServices.
+	AllowEC2 bool `json:"allowEC2,omitempty"`
+
+	// SnapshotFallback uses the last cached or compiled-in copy of the IP
+	// ranges file if it cannot be downloaded.
+	SnapshotFallback bool `json:"snapshotFallback,omitempty"`
+
+	// CacheDir persists the IP ranges file in a directory, in addition to
+	// the in-memory DefaultCache.
+	CacheDir string `json:"cacheDir,omitempty"`
+
+	// MD5 is the expected hex encoded md5 digest of the IP ranges file.
+	MD5 string `json:"md5,omitempty"`
+
+	// SHA256 is the expected hex encoded sha256 digest of the IP ranges file.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Source returns the configured URL, or IPRangesFile if none is configured.
+func (c *Config) Source() string {
+	if c.URL == "" {
+		return IPRangesFile
+	}
+	return c.URL
+}
+
+// Options returns the getter options for the configuration.
+func (c *Config) Options() []Option {
+	opts := []Option{WithExcludedServices(c.ExcludeServices...)}
+
+	if c.MD5 != "" {
+		opts = append(opts, WithMD5(c.MD5))
+	}
+	if c.SHA256 != "" {
+		opts = append(opts, WithSHA256(c.SHA256))
+	}
+	if c.SnapshotFallback {
+		opts = append(opts, WithSnapshotFallback())
+	}
+	if c.AllowEC2 {
+		opts = append(opts, WithEC2Allowed())
+	}
+	if c.IncludeGlobal {
+		opts = append(opts, WithIncludeGlobal())
+	}
+	if len(c.NetworkBorderGroups) > 0 {
+		opts = append(opts, WithNetworkBorderGroups(c.NetworkBorderGroups...))
+	}
+
+	stores := []Store{DefaultCache}
+	if c.CacheDir != "" {
+		stores = append(stores, &FileStore{Dir: c.CacheDir})
+	}
+
+	return append(opts, WithCache(stores...))
+}
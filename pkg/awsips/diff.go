@@ -0,0 +1,164 @@
+package awsips
+
+import (
+	"sort"
+)
+
+// Filter restricts a Diff to the prefixes used by an aws-api-egress event. The
+// JSON field names match the event, so an event can be decoded as a Filter.
+type Filter struct {
+	// Services are the services to compare. All services are compared if
+	// empty.
+	Services []Service `json:"services"`
+
+	// Regions are region selectors, as given to NewIPRangesGetter. All
+	// regions are compared if empty.
+	Regions []string `json:"regions"`
+
+	// IncludeGlobal includes the GLOBAL region.
+	IncludeGlobal bool `json:"includeGlobal"`
+
+	// NetworkBorderGroups are network border group selectors.
+	NetworkBorderGroups []string `json:"networkBorderGroups"`
+}
+
+// Change is the set of prefixes added and removed for a service in a region.
+type Change struct {
+	Service string   `json:"service"`
+	Region  string   `json:"region"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// serviceRegion is a service in a region.
+type serviceRegion struct {
+	service string
+	region  string
+}
+
+// Diff is the difference between two versions of the IP ranges file.
+type Diff struct {
+	OldSyncToken string   `json:"oldSyncToken"`
+	NewSyncToken string   `json:"newSyncToken"`
+	Changes      []Change `json:"changes"`
+}
+
+// Empty returns a boolean for whether nothing changed.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Compare returns the prefixes added and removed between two versions of the
+// IP ranges file, grouped by service and region. If filter is not nil, only
+// matching prefixes are compared.
+func Compare(oldRanges, newRanges *IPRanges, filter *Filter) (*Diff, error) {
+	match := func(Prefix) bool { return true }
+	if filter != nil {
+		var err error
+		if match, err = filter.matcher(); err != nil {
+			return nil, err
+		}
+	}
+
+	oldIndex := index(oldRanges.Prefixes, match)
+	newIndex := index(newRanges.Prefixes, match)
+
+	keys := make(map[serviceRegion]bool)
+	for key := range oldIndex {
+		keys[key] = true
+	}
+	for key := range newIndex {
+		keys[key] = true
+	}
+
+	diff := &Diff{
+		OldSyncToken: oldRanges.SyncToken,
+		NewSyncToken: newRanges.SyncToken,
+		Changes:      make([]Change, 0),
+	}
+
+	for key := range keys {
+		change := Change{
+			Service: key.service,
+			Region:  key.region,
+			Added:   missing(newIndex[key], oldIndex[key]),
+			Removed: missing(oldIndex[key], newIndex[key]),
+		}
+
+		if len(change.Added) > 0 || len(change.Removed) > 0 {
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		if diff.Changes[i].Service != diff.Changes[j].Service {
+			return diff.Changes[i].Service < diff.Changes[j].Service
+		}
+		return diff.Changes[i].Region < diff.Changes[j].Region
+	})
+
+	return diff, nil
+}
+
+// matcher returns a function matching prefixes selected by the filter.
+func (f *Filter) matcher() (func(Prefix) bool, error) {
+	opts := make([]Option, 0)
+	if f.IncludeGlobal {
+		opts = append(opts, WithIncludeGlobal())
+	}
+	if len(f.NetworkBorderGroups) > 0 {
+		opts = append(opts, WithNetworkBorderGroups(f.NetworkBorderGroups...))
+	}
+
+	regions := f.Regions
+	if len(regions) == 0 {
+		regions = []string{"*"}
+		opts = append(opts, WithIncludeGlobal())
+	}
+
+	g := NewIPRangesGetter("", regions, opts...)
+	if g.configErr != nil {
+		return nil, g.configErr
+	}
+
+	services := ServiceNames(f.Services)
+
+	return func(prefix Prefix) bool {
+		if len(services) > 0 && !in(prefix.Service, services) {
+			return false
+		}
+		return g.selected(prefix)
+	}, nil
+}
+
+// index groups matching prefixes by service and region.
+func index(prefixes []Prefix, match func(Prefix) bool) map[serviceRegion]map[string]bool {
+	result := make(map[serviceRegion]map[string]bool)
+
+	for _, prefix := range prefixes {
+		if !match(prefix) {
+			continue
+		}
+
+		key := serviceRegion{service: prefix.Service, region: prefix.Region}
+		if result[key] == nil {
+			result[key] = make(map[string]bool)
+		}
+		result[key][prefix.IPPrefix] = true
+	}
+
+	return result
+}
+
+// missing returns the sorted CIDRs in a which are not in b.
+func missing(a, b map[string]bool) []string {
+	result := make([]string, 0)
+	for cidr := range a {
+		if !b[cidr] {
+			result = append(result, cidr)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
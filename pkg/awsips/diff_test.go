@@ -0,0 +1,97 @@
+package awsips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	oldRanges := &IPRanges{
+		SyncToken: "1549989079",
+		Prefixes: []Prefix{
+			{IPPrefix: "52.92.16.0/20", Region: "us-east-1", Service: "S3"},
+			{IPPrefix: "52.216.0.0/15", Region: "us-east-1", Service: "S3"},
+			{IPPrefix: "52.218.0.0/17", Region: "eu-west-1", Service: "S3"},
+			{IPPrefix: "52.95.110.0/24", Region: "GLOBAL", Service: "AMAZON"},
+			{IPPrefix: "54.231.0.0/17", Region: "us-east-1", Service: "AMAZON"},
+		},
+	}
+
+	newRanges := &IPRanges{
+		SyncToken: "1549989080",
+		Prefixes: []Prefix{
+			{IPPrefix: "52.92.16.0/20", Region: "us-east-1", Service: "S3"},
+			{IPPrefix: "52.217.0.0/16", Region: "us-east-1", Service: "S3"},
+			{IPPrefix: "52.218.0.0/17", Region: "eu-west-1", Service: "S3"},
+			{IPPrefix: "52.218.128.0/17", Region: "eu-west-1", Service: "S3"},
+			{IPPrefix: "54.231.0.0/17", Region: "us-east-1", Service: "AMAZON"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter *Filter
+
+		expect []Change
+		err    bool
+	}{
+		{
+			name: "Unfiltered",
+			expect: []Change{
+				{Service: "AMAZON", Region: "GLOBAL", Added: []string{}, Removed: []string{"52.95.110.0/24"}},
+				{Service: "S3", Region: "eu-west-1", Added: []string{"52.218.128.0/17"}, Removed: []string{}},
+				{Service: "S3", Region: "us-east-1", Added: []string{"52.217.0.0/16"}, Removed: []string{"52.216.0.0/15"}},
+			},
+		},
+		{
+			name:   "ServiceFilter",
+			filter: &Filter{Services: []Service{{Name: "AMAZON"}}},
+			expect: []Change{
+				{Service: "AMAZON", Region: "GLOBAL", Added: []string{}, Removed: []string{"52.95.110.0/24"}},
+			},
+		},
+		{
+			name:   "RegionFilter",
+			filter: &Filter{Services: []Service{{Name: "S3"}, {Name: "AMAZON"}}, Regions: []string{"us-*"}},
+			expect: []Change{
+				{Service: "S3", Region: "us-east-1", Added: []string{"52.217.0.0/16"}, Removed: []string{"52.216.0.0/15"}},
+			},
+		},
+		{
+			name:   "GlobalFilter",
+			filter: &Filter{Regions: []string{"eu-*"}, IncludeGlobal: true},
+			expect: []Change{
+				{Service: "AMAZON", Region: "GLOBAL", Added: []string{}, Removed: []string{"52.95.110.0/24"}},
+				{Service: "S3", Region: "eu-west-1", Added: []string{"52.218.128.0/17"}, Removed: []string{}},
+			},
+		},
+		{
+			name:   "NoChanges",
+			filter: &Filter{Services: []Service{{Name: "CLOUDFRONT"}}},
+			expect: []Change{},
+		},
+		{
+			name:   "InvalidFilter",
+			filter: &Filter{Regions: []string{"/(/"}},
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff, err := Compare(oldRanges, newRanges, test.filter)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "1549989079", diff.OldSyncToken)
+			assert.Equal(t, "1549989080", diff.NewSyncToken)
+			assert.Equal(t, test.expect, diff.Changes)
+			assert.Equal(t, len(test.expect) == 0, diff.Empty())
+		})
+	}
+}
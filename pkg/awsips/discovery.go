@@ -0,0 +1,112 @@
+package awsips
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Count is the number of prefixes for a service in a region.
+type Count struct {
+	Service  string `json:"service"`
+	Region   string `json:"region"`
+	Prefixes int    `json:"prefixes"`
+}
+
+// Services returns every service in the IP ranges file, sorted by name.
+func (g *IPRangesGetter) Services() ([]string, error) {
+	ranges, err := g.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return unique(ranges.Prefixes, func(p Prefix) string { return p.Service }), nil
+}
+
+// Regions returns every region in the IP ranges file, sorted by name.
+func (g *IPRangesGetter) Regions() ([]string, error) {
+	ranges, err := g.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return unique(ranges.Prefixes, func(p Prefix) string { return p.Region }), nil
+}
+
+// Counts returns the number of prefixes for each service and region in the IP
+// ranges file, sorted by service and region.
+func (g *IPRangesGetter) Counts() ([]Count, error) {
+	ranges, err := g.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[Count]int)
+	for _, prefix := range ranges.Prefixes {
+		index[Count{Service: prefix.Service, Region: prefix.Region}]++
+	}
+
+	counts := make([]Count, 0, len(index))
+	for key, n := range index {
+		key.Prefixes = n
+		counts = append(counts, key)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Service != counts[j].Service {
+			return counts[i].Service < counts[j].Service
+		}
+		return counts[i].Region < counts[j].Region
+	})
+
+	return counts, nil
+}
+
+// Validate returns an error if any of the services, the services excluded
+// with WithExcludedServices or the exact region names configured on the
+// getter do not appear in the IP ranges file.
+func (g *IPRangesGetter) Validate(services []string) error {
+	if g.configErr != nil {
+		return g.configErr
+	}
+
+	known, err := g.Services()
+	if err != nil {
+		return err
+	}
+
+	for _, svc := range append(append([]string{}, services...), g.configuredExclusions...) {
+		if !in(svc, known) {
+			return fmt.Errorf("unknown service %q, expected one of: %s", svc, strings.Join(known, ", "))
+		}
+	}
+
+	regions, err := g.Regions()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range g.regions {
+		if s.Literal() && !in(s.Pattern(), regions) {
+			return fmt.Errorf("unknown region %q, expected one of: %s", s.Pattern(), strings.Join(regions, ", "))
+		}
+	}
+
+	return nil
+}
+
+// unique returns the sorted unique keys of a list of prefixes.
+func unique(prefixes []Prefix, key func(Prefix) string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+
+	for _, prefix := range prefixes {
+		if k := key(prefix); !seen[k] {
+			seen[k] = true
+			result = append(result, k)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,109 @@
+package awsips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscovery(t *testing.T) {
+	getter := NewIPRangesGetter("file://testdata/ip-ranges-border-groups.json", nil)
+
+	services, err := getter.Services()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"S3"}, services)
+
+	regions, err := getter.Regions()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GLOBAL", "eu-west-1", "us-east-1", "us-east-2", "us-west-2"}, regions)
+
+	counts, err := getter.Counts()
+	assert.NoError(t, err)
+	assert.Equal(t, []Count{
+		{Service: "S3", Region: "GLOBAL", Prefixes: 1},
+		{Service: "S3", Region: "eu-west-1", Prefixes: 1},
+		{Service: "S3", Region: "us-east-1", Prefixes: 1},
+		{Service: "S3", Region: "us-east-2", Prefixes: 1},
+		{Service: "S3", Region: "us-west-2", Prefixes: 3},
+	}, counts)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		regions  []string
+		opts     []Option
+		services []string
+
+		err bool
+	}{
+		{
+			name:     "Valid",
+			regions:  []string{"us-west-2", "GLOBAL"},
+			services: []string{"AMAZON", "S3"},
+		},
+		{
+			name:     "RegionSelectors",
+			regions:  []string{"us-*", "/^eu-/"},
+			services: []string{"S3"},
+		},
+		{
+			name:     "UnknownService",
+			regions:  []string{"us-west-2"},
+			services: []string{"S33"},
+			err:      true,
+		},
+		{
+			name:     "UnknownExcludedService",
+			regions:  []string{"us-west-2"},
+			opts:     []Option{WithExcludedServices("CLOUDFRNOT")},
+			services: []string{"S3"},
+			err:      true,
+		},
+		{
+			name:     "DefaultExclusionNotInFile",
+			file:     "testdata/ip-ranges-border-groups.json",
+			regions:  []string{"us-west-2"},
+			services: []string{"S3"},
+		},
+		{
+			name:     "ExcludedServiceNotInFile",
+			file:     "testdata/ip-ranges-border-groups.json",
+			regions:  []string{"us-west-2"},
+			opts:     []Option{WithExcludedServices(ServiceEC2)},
+			services: []string{"S3"},
+			err:      true,
+		},
+		{
+			name:     "UnknownRegion",
+			regions:  []string{"us-wets-2"},
+			services: []string{"S3"},
+			err:      true,
+		},
+		{
+			name:     "InvalidRegionSelector",
+			regions:  []string{"/(/"},
+			services: []string{"S3"},
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := test.file
+			if file == "" {
+				file = "testdata/ip-ranges.json"
+			}
+
+			getter := NewIPRangesGetter("file://"+file, test.regions, test.opts...)
+			err := getter.Validate(test.services)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
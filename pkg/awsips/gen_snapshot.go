@@ -0,0 +1,70 @@
+// +build ignore
+
+// This program generates snapshot.go from an IP ranges file, which may be a
+// local path or an http(s):// URL. It is invoked by go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+func main() {
+	out := flag.String("o", "snapshot.go", "output file")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("usage: gen_snapshot [-o file] path|url")
+	}
+
+	data, err := read(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Validate and compact the file.
+	compact := &bytes.Buffer{}
+	if err := json.Compact(compact, data); err != nil {
+		log.Fatal(err)
+	}
+	if bytes.ContainsRune(compact.Bytes(), '`') {
+		log.Fatal("IP ranges file contains a backtick")
+	}
+
+	src := &bytes.Buffer{}
+	fmt.Fprintln(src, "// Code generated by gen_snapshot.go; DO NOT EDIT.")
+	fmt.Fprintln(src)
+	fmt.Fprintln(src, "package awsips")
+	fmt.Fprintln(src)
+	fmt.Fprintln(src, "// snapshot is a copy of IPRangesFile, used if it cannot be downloaded.")
+	fmt.Fprintf(src, "const snapshot = `%s`\n", compact)
+
+	if err := ioutil.WriteFile(*out, src.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// read reads a local path or http(s):// URL.
+func read(src string) ([]byte, error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		return ioutil.ReadFile(src)
+	}
+
+	res, err := http.Get(src)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Got status: %d", res.StatusCode)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
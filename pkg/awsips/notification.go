@@ -0,0 +1,81 @@
+package awsips
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// IPSpaceChangedTopic is the SNS topic which Amazon publishes to whenever the
+// IP ranges file changes.
+const IPSpaceChangedTopic = "arn:aws:sns:us-east-1:806199016981:AmazonIpSpaceChanged"
+
+// IPSpaceChanged is the message published to IPSpaceChangedTopic.
+type IPSpaceChanged struct {
+	CreateTime string `json:"create-time"`
+	SyncToken  string `json:"synctoken"`
+	MD5        string `json:"md5"`
+	URL        string `json:"url"`
+}
+
+// ParseIPSpaceChanged parses the message of an IPSpaceChangedTopic
+// notification.
+func ParseIPSpaceChanged(message string) (*IPSpaceChanged, error) {
+	result := &IPSpaceChanged{}
+	if err := json.Unmarshal([]byte(message), result); err != nil {
+		return nil, err
+	}
+
+	if result.URL == "" {
+		return nil, errors.New("notification has no url")
+	}
+
+	if result.MD5 == "" {
+		return nil, errors.New("notification has no md5")
+	}
+
+	return result, nil
+}
+
+// CheckTopic returns an error if a notification was not published to
+// IPSpaceChangedTopic. Anyone who can publish to a subscribed topic could
+// otherwise choose the IP ranges file.
+func CheckTopic(topicARN string) error {
+	if topicARN != IPSpaceChangedTopic {
+		return fmt.Errorf("notification from unexpected topic %s", topicARN)
+	}
+	return nil
+}
+
+// CheckURL returns an error unless the URL of the notification is
+// IPRangesFile or the configured URL.
+func (n *IPSpaceChanged) CheckURL(configured string) error {
+	for _, allowed := range []string{IPRangesFile, configured} {
+		if sameURL(n.URL, allowed) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("notification has unexpected url %s", n.URL)
+}
+
+// sameURL returns a boolean for whether two URLs have the same scheme, host
+// and path, and neither has a query or user info.
+func sameURL(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(ua.Scheme, ub.Scheme) &&
+		strings.EqualFold(ua.Host, ub.Host) &&
+		ua.Path == ub.Path &&
+		ua.RawQuery == "" && ub.RawQuery == "" &&
+		ua.User == nil && ub.User == nil
+}
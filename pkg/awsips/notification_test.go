@@ -0,0 +1,117 @@
+package awsips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIPSpaceChanged(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+
+		expect *IPSpaceChanged
+		err    bool
+	}{
+		{
+			name:    "Valid",
+			message: `{"create-time":"2019-02-12-16-31-19","synctoken":"1549989079","md5":"2182bff9048fe44d4c1d9b37903fc632","url":"https://ip-ranges.amazonaws.com/ip-ranges.json"}`,
+			expect: &IPSpaceChanged{
+				CreateTime: "2019-02-12-16-31-19",
+				SyncToken:  "1549989079",
+				MD5:        "2182bff9048fe44d4c1d9b37903fc632",
+				URL:        "https://ip-ranges.amazonaws.com/ip-ranges.json",
+			},
+		},
+		{
+			name:    "MissingURL",
+			message: `{"create-time":"2019-02-12-16-31-19","synctoken":"1549989079","md5":"2182bff9048fe44d4c1d9b37903fc632"}`,
+			err:     true,
+		},
+		{
+			name:    "MissingMD5",
+			message: `{"create-time":"2019-02-12-16-31-19","synctoken":"1549989079","url":"https://ip-ranges.amazonaws.com/ip-ranges.json"}`,
+			err:     true,
+		},
+		{
+			name:    "NotJSON",
+			message: "ip space changed",
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ParseIPSpaceChanged(test.message)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func TestIPSpaceChangedChecks(t *testing.T) {
+	assert.NoError(t, CheckTopic(IPSpaceChangedTopic))
+	assert.Error(t, CheckTopic("arn:aws:sns:us-east-1:123456789012:AmazonIpSpaceChanged"))
+
+	tests := []struct {
+		name       string
+		url        string
+		configured string
+
+		err bool
+	}{
+		{
+			name:       "Default",
+			url:        "https://ip-ranges.amazonaws.com/ip-ranges.json",
+			configured: IPRangesFile,
+		},
+		{
+			name:       "DefaultWithMirror",
+			url:        "https://IP-RANGES.amazonaws.com/ip-ranges.json",
+			configured: "s3://mirror/ip-ranges.json",
+		},
+		{
+			name:       "Configured",
+			url:        "s3://mirror/ip-ranges.json",
+			configured: "s3://mirror/ip-ranges.json",
+		},
+		{
+			name:       "WrongHost",
+			url:        "https://ip-ranges.example.com/ip-ranges.json",
+			configured: IPRangesFile,
+			err:        true,
+		},
+		{
+			name:       "WrongScheme",
+			url:        "file:///tmp/ip-ranges.json",
+			configured: IPRangesFile,
+			err:        true,
+		},
+		{
+			name:       "UserInfo",
+			url:        "https://attacker@ip-ranges.amazonaws.com/ip-ranges.json",
+			configured: IPRangesFile,
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &IPSpaceChanged{URL: test.url, MD5: "2182bff9048fe44d4c1d9b37903fc632"}
+			err := n.CheckURL(test.configured)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package awsips
+
+import (
+	"errors"
+)
+
+// CurrentRegionToken is replaced with the region the caller is running in by
+// ResolveRegions.
+const CurrentRegionToken = "@current"
+
+// ResolveRegions replaces CurrentRegionToken in a list of regions with the
+// current region. If paired is not nil, the regions paired with each listed
+// region are also included, for example to allow S3 cross-region replication
+// traffic. Duplicate regions are removed.
+func ResolveRegions(regions []string, current string, paired map[string][]string) ([]string, error) {
+	resolved := make([]string, 0, len(regions))
+	add := func(region string) {
+		if !in(region, resolved) {
+			resolved = append(resolved, region)
+		}
+	}
+
+	for _, region := range regions {
+		if region == CurrentRegionToken {
+			if current == "" {
+				return nil, errors.New("unable to determine the current region")
+			}
+			region = current
+		}
+		add(region)
+	}
+
+	for _, region := range append([]string{}, resolved...) {
+		for _, pair := range paired[region] {
+			add(pair)
+		}
+	}
+
+	return resolved, nil
+}
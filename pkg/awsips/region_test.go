@@ -0,0 +1,67 @@
+package awsips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions []string
+		current string
+		paired  map[string][]string
+
+		expect []string
+		err    bool
+	}{
+		{
+			name:    "NoToken",
+			regions: []string{"us-east-1", "us-west-2"},
+			expect:  []string{"us-east-1", "us-west-2"},
+		},
+		{
+			name:    "CurrentRegion",
+			regions: []string{"@current", "GLOBAL"},
+			current: "eu-west-1",
+			expect:  []string{"eu-west-1", "GLOBAL"},
+		},
+		{
+			name:    "CurrentRegionUnknown",
+			regions: []string{"@current"},
+			err:     true,
+		},
+		{
+			name:    "Duplicates",
+			regions: []string{"@current", "us-east-1"},
+			current: "us-east-1",
+			expect:  []string{"us-east-1"},
+		},
+		{
+			name:    "PairedRegions",
+			regions: []string{"@current"},
+			current: "us-east-1",
+			paired: map[string][]string{
+				"us-east-1": {"us-west-2", "us-east-2"},
+				"us-west-2": {"us-east-1"},
+				"eu-west-1": {"eu-central-1"},
+			},
+			expect: []string{"us-east-1", "us-west-2", "us-east-2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ResolveRegions(test.regions, test.current, test.paired)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
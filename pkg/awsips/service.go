@@ -0,0 +1,112 @@
+package awsips
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// Rule directions.
+const (
+	DirectionEgress  = "egress"
+	DirectionIngress = "ingress"
+)
+
+// Defaults for services which do not specify ports or a protocol.
+const (
+	DefaultPort     = 443
+	DefaultProtocol = rule.ProtocolTCP
+)
+
+// Service is an AWS service to whitelist, and the rules to create for it. In
+// JSON, a service is either an object or just the name of the service, which
+// whitelists HTTPS egress.
+type Service struct {
+	// Name is the name of the service in the IP ranges file.
+	Name string `json:"name"`
+
+	// Ports are the ports to allow traffic to. Defaults to 443.
+	Ports []int `json:"ports"`
+
+	// Protocol is the network protocol. Defaults to tcp.
+	Protocol string `json:"protocol"`
+
+	// Direction is either egress (default) or ingress.
+	Direction string `json:"direction"`
+}
+
+// UnmarshalJSON decodes a service from either a name or an object.
+func (s *Service) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = Service{Name: name}
+		return nil
+	}
+
+	// The alias has no UnmarshalJSON method, which would recurse.
+	type service Service
+	return json.Unmarshal(data, (*service)(s))
+}
+
+// Validate returns an error if the service's rule configuration is invalid.
+func (s Service) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("service has no name")
+	}
+
+	switch s.Protocol {
+	case "", rule.ProtocolTCP, rule.ProtocolUDP:
+	default:
+		return fmt.Errorf("service %s: unsupported protocol %q", s.Name, s.Protocol)
+	}
+
+	switch s.Direction {
+	case "", DirectionEgress, DirectionIngress:
+	default:
+		return fmt.Errorf("service %s: unsupported direction %q", s.Name, s.Direction)
+	}
+
+	for _, port := range s.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("service %s: invalid port %d", s.Name, port)
+		}
+	}
+
+	return nil
+}
+
+// Rules returns a rule for each of the service's ports.
+func (s Service) Rules(cidrs []string) []rule.Rule {
+	ports := s.Ports
+	if len(ports) == 0 {
+		ports = []int{DefaultPort}
+	}
+
+	protocol := s.Protocol
+	if protocol == "" {
+		protocol = DefaultProtocol
+	}
+
+	rules := make([]rule.Rule, len(ports))
+	for i, port := range ports {
+		rules[i] = rule.Rule{
+			Name:     s.Name,
+			Port:     port,
+			Protocol: protocol,
+			Egress:   s.Direction != DirectionIngress,
+			CIDRs:    cidrs,
+		}
+	}
+
+	return rules
+}
+
+// ServiceNames returns the names of a list of services.
+func ServiceNames(services []Service) []string {
+	names := make([]string, len(services))
+	for i := range services {
+		names[i] = services[i].Name
+	}
+	return names
+}
@@ -0,0 +1,94 @@
+package awsips
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceUnmarshal(t *testing.T) {
+	services := make([]Service, 0)
+	err := json.Unmarshal([]byte(`["S3", {"name": "AMAZON", "ports": [5432], "direction": "ingress"}]`), &services)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Service{
+		{Name: "S3"},
+		{Name: "AMAZON", Ports: []int{5432}, Direction: DirectionIngress},
+	}, services)
+
+	err = json.Unmarshal([]byte(`[5432]`), &services)
+	assert.Error(t, err)
+}
+
+func TestServiceValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		service Service
+
+		err bool
+	}{
+		{
+			name:    "NameOnly",
+			service: Service{Name: "S3"},
+		},
+		{
+			name:    "Full",
+			service: Service{Name: "AMAZON", Ports: []int{123}, Protocol: rule.ProtocolUDP, Direction: DirectionEgress},
+		},
+		{
+			name:    "NoName",
+			service: Service{Ports: []int{443}},
+			err:     true,
+		},
+		{
+			name:    "InvalidProtocol",
+			service: Service{Name: "S3", Protocol: "sctp"},
+			err:     true,
+		},
+		{
+			name:    "InvalidDirection",
+			service: Service{Name: "S3", Direction: "outbound"},
+			err:     true,
+		},
+		{
+			name:    "InvalidPort",
+			service: Service{Name: "S3", Ports: []int{443, 70000}},
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.service.Validate()
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServiceRules(t *testing.T) {
+	cidrs := []string{"52.95.174.0/24"}
+
+	assert.Equal(t, []rule.Rule{
+		{Name: "S3", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: cidrs},
+	}, Service{Name: "S3"}.Rules(cidrs))
+
+	assert.Equal(t, []rule.Rule{
+		{Name: "AMAZON", Port: 5432, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: cidrs},
+		{Name: "AMAZON", Port: 5433, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: cidrs},
+	}, Service{Name: "AMAZON", Ports: []int{5432, 5433}}.Rules(cidrs))
+
+	assert.Equal(t, []rule.Rule{
+		{Name: "CLOUDFRONT", Port: 443, Protocol: rule.ProtocolTCP, Egress: false, CIDRs: cidrs},
+	}, Service{Name: "CLOUDFRONT", Direction: DirectionIngress}.Rules(cidrs))
+
+	assert.Equal(t, []rule.Rule{
+		{Name: "AMAZON", Port: 123, Protocol: rule.ProtocolUDP, Egress: true, CIDRs: cidrs},
+	}, Service{Name: "AMAZON", Ports: []int{123}, Protocol: rule.ProtocolUDP}.Rules(cidrs))
+}
@@ -0,0 +1,6 @@
+// Code generated by gen_snapshot.go; DO NOT EDIT.
+
+package awsips
+
+// snapshot is a copy of IPRangesFile, used if it cannot be downloaded.
+const snapshot = `{"syncToken":"1549989079","createDate":"2019-02-12-16-31-19","prefixes":[{"ip_prefix":"18.208.0.0/13","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.245.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.194.0.0/15","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.155.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.196.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.22.0/24","region":"us-gov-east-1","service":"AMAZON"},{"ip_prefix":"52.95.255.112/28","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"13.210.0.0/15","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.94.17.0/24","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.95.154.0/23","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.95.212.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.239.0.240/28","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"54.241.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"184.169.128.0/17","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"216.182.224.0/21","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.74.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.168.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.239.54.0/23","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"13.248.106.0/24","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"52.119.224.0/21","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.219.64.0/22","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"54.238.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"216.182.232.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.92.72.0/22","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"99.82.184.0/22","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"172.96.98.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"13.125.0.0/16","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"13.248.24.0/22","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"13.248.103.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.193.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.95.104.0/22","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.119.249.0/24","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"13.248.100.0/24","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.92.64.0/22","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.93.5.0/24","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.144.193.128/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.250.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"107.20.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.93.8.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.94.224.0/20","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.46.224.0/20","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.95.156.0/24","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"54.180.0.0/15","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"150.222.71.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.30.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.8.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.94.249.64/28","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.92.0.0/17","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.154.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"67.202.0.0/18","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"103.246.148.0/23","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.93.20.17/32","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.0.0/20","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"205.251.246.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.248.112/28","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.92.39.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.95.150.0/24","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.219.60.0/23","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.94.198.32/28","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"54.232.0.0/16","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.93.249.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"207.171.160.0/20","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.92.48.0/22","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.116.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.144.215.200/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"13.248.99.0/24","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.93.37.223/32","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.248.192/28","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.94.20.0/24","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"52.92.0.0/20","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.95.80.0/20","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"184.73.0.0/16","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"46.137.0.0/17","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.249.16/28","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.144.208.64/26","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"3.80.0.0/12","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.40.0.0/14","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.170.0/23","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.124.128.0/17","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"35.181.0.0/16","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.93.138.252/32","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"54.80.0.0/13","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.214.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.254.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.95.40.0/24","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.254.0/24","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"176.32.64.0/19","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"3.224.0.0/12","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.216.0/21","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.192.192/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.196.192/26","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"54.221.0.0/16","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.240.202.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.255.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"18.253.0.0/16","region":"us-gov-east-1","service":"AMAZON"},{"ip_prefix":"52.46.192.0/20","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.82.187.0/24","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.93.139.253/32","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.94.249.112/28","region":"us-gov-east-1","service":"AMAZON"},{"ip_prefix":"54.230.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"13.208.0.0/16","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"52.93.96.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.156.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.231.224.0/21","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.236.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.249.0/24","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"54.244.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"99.82.174.0/24","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.93.12.12/32","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.255.128/28","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.208.0.0/13","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.239.0.208/28","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"103.246.150.0/23","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"13.228.0.0/15","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.94.248.96/28","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.196.0.0/14","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.32.0.0/14","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.252.0/24","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.119.192.0/22","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.222.36.0/22","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"52.18.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.92.56.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.93.21.14/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.19.0/24","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"54.239.52.0/23","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"175.41.192.0/18","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"205.251.228.0/22","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.248.160/28","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"54.151.0.0/17","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"13.54.0.0/15","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.95.142.0/23","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.95.241.0/24","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.231.232.0/21","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.239.128.0/18","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.144.209.192/26","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"99.80.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.46.172.0/22","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.65.0.0/16","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.93.19.236/32","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.94.200.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.119.188.0/22","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.144.194.0/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.150.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"18.200.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.206.0.0/16","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"13.248.128.0/17","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.82.128.0/19","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.95.255.96/28","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.231.128.0/19","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.226.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.239.106.253/32","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.93.149.0/24","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.218.128.0/17","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"76.223.0.0/17","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"99.84.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"18.144.0.0/15","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.90.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.93.138.253/32","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.95.157.0/24","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"52.144.208.192/26","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.10.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.240.230.0/23","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"100.24.0.0/13","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.74.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"3.104.0.0/14","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.80.0.0/16","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"52.95.216.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.119.232.0/21","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.231.244.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"175.41.128.0/18","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.94.32.0/20","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.219.76.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.216.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.239.0.32/28","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.93.34.57/32","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.13.0/24","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.78.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.231.253.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"99.82.160.0/24","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"204.246.160.0/22","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"13.248.97.0/24","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"162.213.232.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.200.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.239.1.16/28","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"185.143.16.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"205.251.244.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.248.0.0/20","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"52.93.112.35/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.29.0/26","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"35.160.0.0/13","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.48.0.0/14","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.198.80/28","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"54.231.0.0/17","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.192.0/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"204.236.128.0/18","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.93.20.16/32","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.144.216.0/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.239.1.0/28","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"13.48.0.0/15","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.64.0.0/17","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.95.239.0/24","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.119.210.0/23","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"35.155.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.210.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.239.2.0/23","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.93.34.56/32","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.198.16/28","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.144.225.128/26","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"15.177.0.0/21","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.5.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.199.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.240.199.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.198.0.0/16","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.69.0/24","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.94.120.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"13.248.98.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.20.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.248.208/28","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.219.20.0/22","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.219.24.0/21","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"99.82.161.0/24","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"15.177.40.0/21","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"46.137.192.0/19","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.200.0.0/13","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.219.96.0/20","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"54.222.32.0/22","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"205.251.232.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.76.0.0/17","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.93.48.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.216.6/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.240.220.0/22","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.211.196/31","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.219.72.0/22","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.153.128.0/17","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.222.58.0/28","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"122.248.192.0/18","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.207.0.0/16","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"35.154.0.0/16","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"52.82.0.0/17","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.94.249.32/28","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"54.239.0.160/28","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.240.227.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.94.23.0/24","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.95.48.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.219.120.0/22","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.240.232.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.224.64/26","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.170.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"99.82.171.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.4.0/24","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.94.72.0/22","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.222.48.0/22","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"54.240.228.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"176.32.120.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.210.192/26","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.144.211.200/31","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.219.56.0/22","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"54.160.0.0/13","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"157.175.0.0/16","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"176.34.32.0/19","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.239.108.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"18.236.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.249.80/28","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.240.198.0/24","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"205.251.192.0/19","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"46.51.192.0/20","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.174.0/24","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"54.239.106.252/32","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.94.96.0/20","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.94.192.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.231.248.0/22","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"178.236.0.0/20","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"35.176.0.0/15","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"54.239.112.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.34.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"205.251.247.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"35.153.0.0/16","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.61.0.0/16","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.79.0.0/16","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"54.239.107.252/32","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.94.16.0/24","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.144.195.0/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.248.105.0/24","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"52.58.0.0/15","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.218.0.0/17","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.62.0.0/15","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.93.0.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.93.19.237/32","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.219.44.0/22","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.239.192.0/19","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"99.82.162.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"150.222.70.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"54.239.0.144/28","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"46.51.216.0/21","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.28.0.0/16","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.46.166.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.46.176.0/22","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.57.0.0/16","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.94.124.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.211.192/31","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"15.177.32.0/21","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.70.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.248.0/28","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.119.212.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.216.10/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.239.99.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.29.0.0/16","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.94.15.0/24","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.95.35.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.95.62.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.144.0/24","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.144.194.64/26","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.144.209.0/26","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.144.211.198/31","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"184.72.0.0/18","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.95.246.0/24","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.26.0/23","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.247.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.248.0.0/15","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"27.0.0.0/22","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.46.180.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.93.1.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"15.177.48.0/21","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.94.198.144/28","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.94.208.0/21","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.227.0/24","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.68.0.0/14","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.93.0.0/16","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"70.132.0.0/18","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.54.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.93.3.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.240.225.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"18.182.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.152.0.0/16","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.32.0.0/15","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"13.112.0.0/14","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.68.0.0/15","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.67.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"99.82.173.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"18.194.0.0/15","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.46.64.0/20","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.94.197.0/24","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.249.128/28","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.144.193.64/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.184.0.0/13","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.239.16.0/20","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"99.82.163.0/24","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.92.128.0/17","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.239.0.0/28","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.0.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.253.0/24","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"54.239.120.0/21","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"140.179.0.0/16","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"13.53.0.0/16","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.94.48.0/20","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"184.72.128.0/17","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"205.251.248.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.224.0.0/14","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.119.240.0/21","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.239.0.80/28","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.240.216.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"99.82.128.0/20","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"99.82.166.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.58.0.0/15","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.93.51.29/32","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.194.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.240.244.0/22","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"35.156.0.0/14","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.93.18.178/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.209.64/26","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"23.20.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.46.168.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.93.151.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.94.248.80/28","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.92.16.0/20","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.64.0/20","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"52.95.225.0/24","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"172.96.97.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"18.229.0.0/16","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.94.68.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.192.0/20","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"54.219.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"18.204.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"35.178.0.0/15","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.94.9.0/24","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.94.204.0/23","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.88.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"75.2.0.0/17","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.12.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.249.0/28","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"52.220.0.0/15","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.231.252.0/24","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"162.250.236.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.35.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"34.240.0.0/13","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.93.14.19/32","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.248.16/28","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.249.96/28","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"52.144.216.8/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.240.200.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.253.0.0/16","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.46.240.0/22","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.28.0/23","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.248.128/28","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.239.100.0/23","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"204.246.172.0/23","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.94.248.64/28","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.72.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.239.0.192/28","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.94.11.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.240.196.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"204.246.164.0/22","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"54.223.0.0/16","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"54.239.0.48/28","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.24.0/22","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.119.196.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"79.125.0.0/17","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.88.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.219.0.0/20","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.240.248.0/21","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.248.32/28","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.219.40.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.220.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"100.20.0.0/14","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.24.0/23","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"3.8.0.0/14","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"18.246.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.93.139.252/32","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.94.198.0/28","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.204.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.163.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"216.182.236.0/23","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"34.208.0.0/12","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.15.0.0/16","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.93.17.16/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.209.128/26","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.95.30.0/23","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.95.96.0/22","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"52.95.145.0/24","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.86.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.44.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.76.128.0/17","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.92.40.0/21","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.219.32.0/21","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.95.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.212.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"150.222.69.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"18.232.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.239.0.224/28","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"54.239.48.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"15.177.56.0/21","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.47.0.0/16","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.93.16.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.136.0/23","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.95.255.64/28","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.225.64/26","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"204.246.168.0/22","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.219.62.0/23","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"99.82.175.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.46.208.0/21","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.93.51.28/32","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.12.0/24","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"63.32.0.0/14","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"15.177.24.0/21","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.83.0.0/16","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.93.14.18/32","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.6.0/24","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.144.197.192/26","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"150.222.2.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.79.0.0/16","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.251.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.95.52.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"18.153.0.0/16","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"18.202.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.239.1.48/28","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"176.32.104.0/21","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"15.177.16.0/21","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"18.196.0.0/15","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.76.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.80.0/20","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.94.198.112/28","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.240.197.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"71.152.0.0/17","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"216.137.32.0/19","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.46.252.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.255.16/28","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"13.232.0.0/14","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"52.95.243.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.219.80.0/20","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"54.174.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"50.16.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"205.251.249.0/24","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.52.0.0/15","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.144.197.128/26","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.233.64.0/18","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"35.168.0.0/13","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.64.128.0/17","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.92.80.0/22","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.94.198.48/28","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.95.228.0/24","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"54.222.128.0/17","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"96.127.0.0/17","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.119.252.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.148.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"35.182.0.0/15","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"3.112.0.0/14","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.95.244.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"99.82.188.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.148.0/23","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"3.208.0.0/12","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.92.88.0/22","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"185.48.120.0/22","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.192.64/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.240.192.0/22","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"18.220.0.0/14","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.36.0.0/14","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.94.112.0/22","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"54.94.0.0/16","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"15.177.8.0/21","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"18.191.0.0/16","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.144.210.0/26","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.169.0/24","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.222.0.0/19","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"54.239.0.112/28","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.239.8.0/21","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.240.204.0/22","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"99.86.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"207.171.176.0/20","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.164.0/23","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.144.208.128/26","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.202.0.0/15","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.240.208.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.240.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.210.64/26","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"13.248.104.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"34.248.0.0/13","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.93.237.0/24","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.239.107.253/32","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"50.18.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.14.0.0/16","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.46.0.0/18","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.46.88.0/22","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.93.17.17/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"13.124.0.0/16","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.84.0.0/15","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.94.248.144/28","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"52.192.0.0/15","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.95.255.32/28","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"160.1.0.0/16","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"13.236.0.0/14","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.46.220.0/22","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.92.32.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.100.0/22","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.95.172.0/23","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"174.129.0.0/16","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.209.0.0/16","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.60.0.0/16","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.78.0.0/16","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"72.44.32.0/19","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"205.251.236.0/22","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"34.224.0.0/12","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.75.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.144.215.194/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"99.82.164.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.92.68.0/22","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.94.0.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"205.251.240.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"13.230.0.0/15","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.93.4.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.198.96/28","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.144.194.128/26","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.144.210.128/26","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.144.211.202/31","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.219.112.0/21","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.224.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.239.32.0/21","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"15.164.0.0/15","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.46.96.0/19","region":"us-gov-east-1","service":"AMAZON"},{"ip_prefix":"52.46.128.0/19","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.239.0.128/28","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"176.34.128.0/17","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.240.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.219.16.0/22","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"75.101.128.0/17","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.46.164.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.178.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"99.82.168.0/24","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"108.128.0.0/13","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.61.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"13.56.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"18.184.0.0/15","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"72.21.192.0/19","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.63.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.252.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.215.198/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.222.57.0/24","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"99.83.128.0/17","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"18.216.0.0/14","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"34.192.0.0/12","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.93.37.222/32","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.64.0/22","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"54.231.160.0/19","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.93.18.179/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.93.112.34/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.196.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"54.215.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"177.71.128.0/17","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"18.175.0.0/16","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.46.216.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.92.76.0/22","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"54.208.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.228.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"64.252.64.0/18","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.92.52.0/22","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.92.60.0/22","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.144.215.192/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.219.68.0/22","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.229.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.14.0/24","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.94.198.64/28","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.119.216.0/21","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"18.138.0.0/15","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.95.255.144/28","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"204.246.174.0/23","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"3.120.0.0/14","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.9.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.144.216.4/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"52.94.248.48/28","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"54.242.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"177.72.240.0/21","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"216.182.238.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"35.180.0.0/16","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.94.76.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.95.36.0/22","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"18.228.0.0/16","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.16.0.0/15","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.28.0/24","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.95.146.0/23","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.95.242.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"13.52.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"46.137.128.0/18","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.93.2.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.248.176/28","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"52.95.16.0/21","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"54.234.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"18.188.0.0/16","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"46.51.128.0/18","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"64.252.128.0/18","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"99.82.152.0/22","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"99.82.167.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"205.251.254.0/24","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.94.254.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.153.0.0/17","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.24.0.0/14","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.46.170.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.56.0/22","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.119.160.0/20","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.222.0.0/17","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.82.192.0/18","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.93.12.13/32","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"54.239.96.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.240.226.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.94.248.224/28","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.95.255.48/28","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"54.218.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"3.124.0.0/14","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.82.176.0/22","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.144.194.192/26","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.183.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"13.248.101.0/24","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.95.255.0/28","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"54.176.0.0/15","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.246.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.108.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.193.0/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"143.204.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"18.231.0.0/16","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"54.252.0.0/16","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"46.137.224.0/19","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.92.248.0/22","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"99.82.156.0/22","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.94.199.0/24","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.95.128.0/21","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.119.206.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"205.251.252.0/23","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.119.176.0/21","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.144.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.169.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.66.0.0/16","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"52.2.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"103.4.8.0/21","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"176.32.96.0/21","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"184.72.64.0/18","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.94.244.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.119.208.0/23","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.95.112.0/20","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"18.179.0.0/16","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.95.138.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"205.251.224.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.95.110.0/24","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"46.51.224.0/19","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.95.111.0/24","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"54.179.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.240.203.0/24","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"54.233.0.0/18","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"99.82.172.0/24","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.119.184.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.144.211.194/31","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"54.239.104.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"204.246.176.0/20","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.8.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.95.247.0/24","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.66.0.0/16","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"54.239.0.64/28","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"99.82.176.0/21","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"204.236.192.0/18","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.64.0.0/15","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"103.8.172.0/22","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"176.34.0.0/19","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"13.248.96.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.158.0/23","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"52.144.192.128/26","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.216.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"99.82.144.0/21","region":"me-south-1","service":"AMAZON"},{"ip_prefix":"99.82.169.0/24","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.94.198.128/28","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.95.248.0/24","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.239.0.176/28","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"52.46.92.0/22","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.93.236.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"54.239.98.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.82.188.0/22","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"54.231.240.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"176.32.125.0/25","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"13.249.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"13.248.28.0/22","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"54.239.56.0/21","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"99.82.165.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"3.0.0.0/15","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"13.248.102.0/24","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"43.250.193.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.77.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.93.21.15/32","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.119.205.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.239.1.64/28","region":"us-gov-east-1","service":"AMAZON"},{"ip_prefix":"18.224.0.0/14","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.56.0.0/16","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"54.240.212.0/22","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.245.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"43.250.192.0/24","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.239.113.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"176.32.112.0/21","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"52.94.10.0/24","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"99.82.170.0/24","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"52.94.7.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"52.95.60.0/24","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"13.248.16.0/21","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"52.92.84.0/22","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"54.240.128.0/18","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"150.222.12.0/24","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"205.251.250.0/23","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.144.211.128/26","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.95.251.0/24","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"52.4.0.0/14","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.46.80.0/21","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"52.46.184.0/22","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"52.67.0.0/16","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"54.239.116.0/22","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"18.201.0.0/16","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.119.214.0/23","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.215.202/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"54.151.128.0/17","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.81.0.0/16","region":"cn-north-1","service":"AMAZON"},{"ip_prefix":"52.222.128.0/17","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"13.250.0.0/15","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"52.95.166.0/23","region":"us-gov-east-1","service":"AMAZON"},{"ip_prefix":"52.144.216.2/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"3.16.0.0/14","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"18.130.0.0/16","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"52.72.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.82.180.0/22","region":"cn-northwest-1","service":"AMAZON"},{"ip_prefix":"54.182.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"52.95.168.0/24","region":"us-gov-east-1","service":"AMAZON"},{"ip_prefix":"52.144.224.128/26","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.192.0.0/16","region":"GLOBAL","service":"AMAZON"},{"ip_prefix":"54.239.0.16/28","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"54.239.0.96/28","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"18.136.0.0/16","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"50.112.0.0/16","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"52.93.97.0/24","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.144.215.196/31","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"87.238.80.0/21","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.95.255.80/28","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"52.92.252.0/22","region":"us-gov-west-1","service":"AMAZON"},{"ip_prefix":"52.95.250.0/24","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"52.144.211.0/26","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"50.19.0.0/16","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"99.79.0.0/16","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"13.57.0.0/16","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"13.126.0.0/15","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"54.239.4.0/22","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.172.0.0/15","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"176.34.64.0/18","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"52.94.206.0/23","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"54.231.192.0/20","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"54.233.128.0/17","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"203.83.220.0/22","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"54.245.168.0/26","region":"us-west-2","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.0.0/21","region":"us-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.40.0/21","region":"ap-northeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.243.31.192/26","region":"us-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"177.71.207.128/26","region":"sa-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.255.254.192/26","region":"ap-southeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.32.0/21","region":"eu-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.48.0/21","region":"ap-southeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.244.52.192/26","region":"us-west-2","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"176.34.159.192/26","region":"eu-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.251.31.128/26","region":"ap-southeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.183.255.128/26","region":"us-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.56.0/21","region":"ap-southeast-2","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.24.0/21","region":"sa-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.16.0/21","region":"us-west-2","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"15.177.8.0/21","region":"us-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.241.32.64/26","region":"us-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.252.254.192/26","region":"ap-southeast-2","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"107.23.255.0/26","region":"us-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.248.220.0/26","region":"ap-northeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.228.16.0/26","region":"eu-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.250.253.192/26","region":"ap-northeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.232.40.64/26","region":"sa-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"54.252.79.128/26","region":"ap-southeast-2","service":"ROUTE53_HEALTHCHECKS"},{"ip_prefix":"52.95.154.0/23","region":"eu-west-3","service":"S3"},{"ip_prefix":"52.219.64.0/22","region":"ap-south-1","service":"S3"},{"ip_prefix":"52.92.72.0/22","region":"sa-east-1","service":"S3"},{"ip_prefix":"52.92.64.0/22","region":"sa-east-1","service":"S3"},{"ip_prefix":"52.95.156.0/24","region":"eu-west-3","service":"S3"},{"ip_prefix":"52.92.39.0/24","region":"sa-east-1","service":"S3"},{"ip_prefix":"52.95.150.0/24","region":"eu-west-2","service":"S3"},{"ip_prefix":"52.219.60.0/23","region":"ap-northeast-2","service":"S3"},{"ip_prefix":"52.92.48.0/22","region":"us-west-1","service":"S3"},{"ip_prefix":"52.92.0.0/20","region":"ap-northeast-2","service":"S3"},{"ip_prefix":"52.95.170.0/23","region":"eu-north-1","service":"S3"},{"ip_prefix":"54.231.224.0/21","region":"ap-northeast-1","service":"S3"},{"ip_prefix":"52.92.56.0/22","region":"ap-southeast-1","service":"S3"},{"ip_prefix":"52.95.142.0/23","region":"us-gov-west-1","service":"S3"},{"ip_prefix":"54.231.232.0/21","region":"us-west-1","service":"S3"},{"ip_prefix":"54.231.128.0/19","region":"eu-west-1","service":"S3"},{"ip_prefix":"52.218.128.0/17","region":"us-west-2","service":"S3"},{"ip_prefix":"52.95.157.0/24","region":"ap-northeast-3","service":"S3"},{"ip_prefix":"52.219.76.0/22","region":"ap-southeast-1","service":"S3"},{"ip_prefix":"54.231.253.0/24","region":"sa-east-1","service":"S3"},{"ip_prefix":"54.231.0.0/17","region":"us-east-1","service":"S3"},{"ip_prefix":"52.219.20.0/22","region":"us-west-1","service":"S3"},{"ip_prefix":"52.219.24.0/21","region":"us-west-1","service":"S3"},{"ip_prefix":"52.219.96.0/20","region":"us-east-2","service":"S3"},{"ip_prefix":"52.219.72.0/22","region":"eu-central-1","service":"S3"},{"ip_prefix":"52.219.120.0/22","region":"us-west-1","service":"S3"},{"ip_prefix":"54.222.48.0/22","region":"cn-north-1","service":"S3"},{"ip_prefix":"52.219.56.0/22","region":"ap-northeast-2","service":"S3"},{"ip_prefix":"52.95.174.0/24","region":"me-south-1","service":"S3"},{"ip_prefix":"54.231.248.0/22","region":"ap-southeast-2","service":"S3"},{"ip_prefix":"52.218.0.0/17","region":"eu-west-1","service":"S3"},{"ip_prefix":"52.219.44.0/22","region":"eu-central-1","service":"S3"},{"ip_prefix":"52.95.144.0/24","region":"us-gov-west-1","service":"S3"},{"ip_prefix":"52.92.16.0/20","region":"us-east-1","service":"S3"},{"ip_prefix":"54.231.252.0/24","region":"ap-southeast-2","service":"S3"},{"ip_prefix":"52.219.0.0/20","region":"ap-northeast-1","service":"S3"},{"ip_prefix":"52.219.40.0/22","region":"ap-southeast-1","service":"S3"},{"ip_prefix":"52.95.163.0/24","region":"sa-east-1","service":"S3"},{"ip_prefix":"52.95.145.0/24","region":"ca-central-1","service":"S3"},{"ip_prefix":"52.92.40.0/21","region":"eu-west-1","service":"S3"},{"ip_prefix":"52.219.32.0/21","region":"ap-southeast-1","service":"S3"},{"ip_prefix":"52.95.136.0/23","region":"sa-east-1","service":"S3"},{"ip_prefix":"52.219.62.0/23","region":"ap-south-1","service":"S3"},{"ip_prefix":"52.219.80.0/20","region":"us-east-2","service":"S3"},{"ip_prefix":"52.92.80.0/22","region":"ap-northeast-1","service":"S3"},{"ip_prefix":"52.95.148.0/23","region":"eu-west-2","service":"S3"},{"ip_prefix":"52.92.88.0/22","region":"eu-west-2","service":"S3"},{"ip_prefix":"52.95.169.0/24","region":"eu-north-1","service":"S3"},{"ip_prefix":"52.95.164.0/23","region":"sa-east-1","service":"S3"},{"ip_prefix":"52.92.32.0/22","region":"us-west-2","service":"S3"},{"ip_prefix":"52.95.172.0/23","region":"me-south-1","service":"S3"},{"ip_prefix":"52.92.68.0/22","region":"eu-central-1","service":"S3"},{"ip_prefix":"52.219.112.0/21","region":"us-west-1","service":"S3"},{"ip_prefix":"52.219.16.0/22","region":"ap-northeast-1","service":"S3"},{"ip_prefix":"54.231.160.0/19","region":"us-west-2","service":"S3"},{"ip_prefix":"52.92.76.0/22","region":"us-east-2","service":"S3"},{"ip_prefix":"52.92.52.0/22","region":"ap-southeast-2","service":"S3"},{"ip_prefix":"52.92.60.0/22","region":"ap-northeast-1","service":"S3"},{"ip_prefix":"52.219.68.0/22","region":"ap-northeast-1","service":"S3"},{"ip_prefix":"52.95.146.0/23","region":"ca-central-1","service":"S3"},{"ip_prefix":"52.92.248.0/22","region":"ap-south-1","service":"S3"},{"ip_prefix":"52.95.128.0/21","region":"ap-southeast-2","service":"S3"},{"ip_prefix":"52.95.138.0/24","region":"sa-east-1","service":"S3"},{"ip_prefix":"52.95.158.0/23","region":"ap-northeast-3","service":"S3"},{"ip_prefix":"52.216.0.0/15","region":"us-east-1","service":"S3"},{"ip_prefix":"52.82.188.0/22","region":"cn-northwest-1","service":"S3"},{"ip_prefix":"54.231.240.0/22","region":"ap-southeast-1","service":"S3"},{"ip_prefix":"52.92.84.0/22","region":"ca-central-1","service":"S3"},{"ip_prefix":"52.95.166.0/23","region":"us-gov-east-1","service":"S3"},{"ip_prefix":"52.95.168.0/24","region":"us-gov-east-1","service":"S3"},{"ip_prefix":"52.92.252.0/22","region":"us-gov-west-1","service":"S3"},{"ip_prefix":"54.231.192.0/20","region":"eu-central-1","service":"S3"},{"ip_prefix":"18.208.0.0/13","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.95.245.0/24","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.194.0.0/15","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.155.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.196.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.95.255.112/28","region":"us-west-2","service":"EC2"},{"ip_prefix":"13.210.0.0/15","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"54.241.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"184.169.128.0/17","region":"us-west-1","service":"EC2"},{"ip_prefix":"216.182.224.0/21","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.74.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.168.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.238.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"216.182.232.0/22","region":"us-east-1","service":"EC2"},{"ip_prefix":"13.125.0.0/16","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"54.193.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"54.250.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"107.20.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.180.0.0/15","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"52.30.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.94.249.64/28","region":"us-west-2","service":"EC2"},{"ip_prefix":"54.92.0.0/17","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.154.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"67.202.0.0/18","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.94.248.112/28","region":"eu-central-1","service":"EC2"},{"ip_prefix":"54.232.0.0/16","region":"sa-east-1","service":"EC2"},{"ip_prefix":"52.94.116.0/22","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.94.248.192/28","region":"eu-west-2","service":"EC2"},{"ip_prefix":"184.73.0.0/16","region":"us-east-1","service":"EC2"},{"ip_prefix":"46.137.0.0/17","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.94.249.16/28","region":"cn-northwest-1","service":"EC2"},{"ip_prefix":"3.80.0.0/12","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.40.0.0/14","region":"us-west-2","service":"EC2"},{"ip_prefix":"35.181.0.0/16","region":"eu-west-3","service":"EC2"},{"ip_prefix":"54.80.0.0/13","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.214.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"54.254.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.95.254.0/24","region":"eu-west-3","service":"EC2"},{"ip_prefix":"176.32.64.0/19","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"3.224.0.0/12","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.221.0.0/16","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.255.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"18.253.0.0/16","region":"us-gov-east-1","service":"EC2"},{"ip_prefix":"52.94.249.112/28","region":"us-gov-east-1","service":"EC2"},{"ip_prefix":"13.208.0.0/16","region":"ap-northeast-3","service":"EC2"},{"ip_prefix":"54.156.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.236.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.95.249.0/24","region":"ap-south-1","service":"EC2"},{"ip_prefix":"54.244.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.95.255.128/28","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.208.0.0/13","region":"eu-west-1","service":"EC2"},{"ip_prefix":"13.228.0.0/15","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.94.248.96/28","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.196.0.0/14","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.32.0.0/14","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.95.252.0/24","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"54.222.36.0/22","region":"cn-north-1","service":"EC2"},{"ip_prefix":"52.18.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"175.41.192.0/18","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.94.248.160/28","region":"us-east-2","service":"EC2"},{"ip_prefix":"54.151.0.0/17","region":"us-west-1","service":"EC2"},{"ip_prefix":"13.54.0.0/15","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.95.241.0/24","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"99.80.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.65.0.0/16","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"54.150.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"18.200.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.206.0.0/16","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.95.255.96/28","region":"us-west-1","service":"EC2"},{"ip_prefix":"54.226.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"18.144.0.0/15","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.90.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.10.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"100.24.0.0/13","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.74.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"3.104.0.0/14","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.80.0.0/16","region":"cn-north-1","service":"EC2"},{"ip_prefix":"175.41.128.0/18","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.216.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.78.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"162.213.232.0/24","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.200.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"35.160.0.0/13","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.48.0.0/14","region":"eu-west-1","service":"EC2"},{"ip_prefix":"204.236.128.0/18","region":"us-west-1","service":"EC2"},{"ip_prefix":"13.48.0.0/15","region":"eu-north-1","service":"EC2"},{"ip_prefix":"52.64.0.0/17","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.95.239.0/24","region":"eu-west-2","service":"EC2"},{"ip_prefix":"35.155.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"54.210.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"15.177.0.0/21","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.199.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.198.0.0/16","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.20.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.94.248.208/28","region":"ca-central-1","service":"EC2"},{"ip_prefix":"15.177.40.0/21","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"46.137.192.0/19","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.200.0.0/13","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.222.32.0/22","region":"cn-north-1","service":"EC2"},{"ip_prefix":"52.76.0.0/17","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.153.128.0/17","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"122.248.192.0/18","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.207.0.0/16","region":"sa-east-1","service":"EC2"},{"ip_prefix":"35.154.0.0/16","region":"ap-south-1","service":"EC2"},{"ip_prefix":"52.82.0.0/17","region":"cn-northwest-1","service":"EC2"},{"ip_prefix":"52.94.249.32/28","region":"eu-west-3","service":"EC2"},{"ip_prefix":"54.170.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.160.0.0/13","region":"us-east-1","service":"EC2"},{"ip_prefix":"157.175.0.0/16","region":"me-south-1","service":"EC2"},{"ip_prefix":"176.34.32.0/19","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"18.236.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.94.249.80/28","region":"us-west-1","service":"EC2"},{"ip_prefix":"46.51.192.0/20","region":"eu-west-1","service":"EC2"},{"ip_prefix":"35.176.0.0/15","region":"eu-west-2","service":"EC2"},{"ip_prefix":"35.153.0.0/16","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.61.0.0/16","region":"us-gov-west-1","service":"EC2"},{"ip_prefix":"52.79.0.0/16","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"52.58.0.0/15","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.62.0.0/15","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"46.51.216.0/21","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.28.0.0/16","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.57.0.0/16","region":"eu-central-1","service":"EC2"},{"ip_prefix":"15.177.32.0/21","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.70.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.94.248.0/28","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.29.0.0/16","region":"eu-central-1","service":"EC2"},{"ip_prefix":"184.72.0.0/18","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.95.246.0/24","region":"us-west-1","service":"EC2"},{"ip_prefix":"54.247.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.248.0.0/15","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.46.180.0/22","region":"us-west-2","service":"EC2"},{"ip_prefix":"15.177.48.0/21","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.95.227.0/24","region":"eu-north-1","service":"EC2"},{"ip_prefix":"54.68.0.0/14","region":"us-west-2","service":"EC2"},{"ip_prefix":"54.93.0.0/16","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.54.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"18.182.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.152.0.0/16","region":"us-east-1","service":"EC2"},{"ip_prefix":"13.112.0.0/14","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.68.0.0/15","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.67.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"18.194.0.0/15","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.94.249.128/28","region":"eu-north-1","service":"EC2"},{"ip_prefix":"54.184.0.0/13","region":"us-west-2","service":"EC2"},{"ip_prefix":"54.92.128.0/17","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.0.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.95.253.0/24","region":"eu-west-2","service":"EC2"},{"ip_prefix":"140.179.0.0/16","region":"cn-north-1","service":"EC2"},{"ip_prefix":"13.53.0.0/16","region":"eu-north-1","service":"EC2"},{"ip_prefix":"184.72.128.0/17","region":"us-east-1","service":"EC2"},{"ip_prefix":"13.58.0.0/15","region":"us-east-2","service":"EC2"},{"ip_prefix":"54.194.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"35.156.0.0/14","region":"eu-central-1","service":"EC2"},{"ip_prefix":"23.20.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.94.248.80/28","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.95.225.0/24","region":"ap-northeast-3","service":"EC2"},{"ip_prefix":"18.229.0.0/16","region":"sa-east-1","service":"EC2"},{"ip_prefix":"54.219.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"18.204.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"35.178.0.0/15","region":"eu-west-2","service":"EC2"},{"ip_prefix":"54.88.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.12.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.94.249.0/28","region":"cn-north-1","service":"EC2"},{"ip_prefix":"52.220.0.0/15","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"162.250.236.0/24","region":"us-east-1","service":"EC2"},{"ip_prefix":"34.240.0.0/13","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.94.248.16/28","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.94.249.96/28","region":"ap-northeast-3","service":"EC2"},{"ip_prefix":"54.253.0.0/16","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.94.248.128/28","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.94.248.64/28","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"54.72.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.223.0.0/16","region":"cn-north-1","service":"EC2"},{"ip_prefix":"79.125.0.0/17","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.88.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.94.248.32/28","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.220.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"100.20.0.0/14","region":"us-west-2","service":"EC2"},{"ip_prefix":"3.8.0.0/14","region":"eu-west-2","service":"EC2"},{"ip_prefix":"18.246.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"54.204.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"216.182.236.0/23","region":"us-west-1","service":"EC2"},{"ip_prefix":"34.208.0.0/12","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.15.0.0/16","region":"us-east-2","service":"EC2"},{"ip_prefix":"52.86.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.44.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.76.128.0/17","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.95.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.212.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"18.232.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"15.177.56.0/21","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.47.0.0/16","region":"eu-west-3","service":"EC2"},{"ip_prefix":"52.95.255.64/28","region":"eu-west-1","service":"EC2"},{"ip_prefix":"63.32.0.0/14","region":"eu-west-1","service":"EC2"},{"ip_prefix":"15.177.24.0/21","region":"sa-east-1","service":"EC2"},{"ip_prefix":"52.83.0.0/16","region":"cn-northwest-1","service":"EC2"},{"ip_prefix":"54.79.0.0/16","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"54.251.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"18.153.0.0/16","region":"eu-central-1","service":"EC2"},{"ip_prefix":"18.202.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"15.177.16.0/21","region":"us-west-2","service":"EC2"},{"ip_prefix":"18.196.0.0/15","region":"eu-central-1","service":"EC2"},{"ip_prefix":"54.76.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.95.255.16/28","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"13.232.0.0/14","region":"ap-south-1","service":"EC2"},{"ip_prefix":"52.95.243.0/24","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.174.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"50.16.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.52.0.0/15","region":"us-west-1","service":"EC2"},{"ip_prefix":"54.233.64.0/18","region":"sa-east-1","service":"EC2"},{"ip_prefix":"35.168.0.0/13","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.64.128.0/17","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.95.228.0/24","region":"me-south-1","service":"EC2"},{"ip_prefix":"54.222.128.0/17","region":"cn-north-1","service":"EC2"},{"ip_prefix":"96.127.0.0/17","region":"us-gov-west-1","service":"EC2"},{"ip_prefix":"54.148.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"35.182.0.0/15","region":"ca-central-1","service":"EC2"},{"ip_prefix":"3.112.0.0/14","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.95.244.0/24","region":"eu-west-1","service":"EC2"},{"ip_prefix":"3.208.0.0/12","region":"us-east-1","service":"EC2"},{"ip_prefix":"185.48.120.0/22","region":"eu-west-1","service":"EC2"},{"ip_prefix":"18.220.0.0/14","region":"us-east-2","service":"EC2"},{"ip_prefix":"52.36.0.0/14","region":"us-west-2","service":"EC2"},{"ip_prefix":"54.94.0.0/16","region":"sa-east-1","service":"EC2"},{"ip_prefix":"15.177.8.0/21","region":"us-west-1","service":"EC2"},{"ip_prefix":"18.191.0.0/16","region":"us-east-2","service":"EC2"},{"ip_prefix":"54.202.0.0/15","region":"us-west-2","service":"EC2"},{"ip_prefix":"34.248.0.0/13","region":"eu-west-1","service":"EC2"},{"ip_prefix":"50.18.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.14.0.0/16","region":"us-east-2","service":"EC2"},{"ip_prefix":"13.124.0.0/16","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"52.94.248.144/28","region":"ap-south-1","service":"EC2"},{"ip_prefix":"52.192.0.0/15","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.95.255.32/28","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"160.1.0.0/16","region":"us-gov-west-1","service":"EC2"},{"ip_prefix":"13.236.0.0/14","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"174.129.0.0/16","region":"us-east-1","service":"EC2"},{"ip_prefix":"13.209.0.0/16","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"52.60.0.0/16","region":"ca-central-1","service":"EC2"},{"ip_prefix":"52.78.0.0/16","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"72.44.32.0/19","region":"us-east-1","service":"EC2"},{"ip_prefix":"34.224.0.0/12","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.75.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"13.230.0.0/15","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.224.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"15.164.0.0/15","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"176.34.128.0/17","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.95.240.0/24","region":"sa-east-1","service":"EC2"},{"ip_prefix":"75.101.128.0/17","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.178.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"108.128.0.0/13","region":"eu-west-1","service":"EC2"},{"ip_prefix":"13.56.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"18.184.0.0/15","region":"eu-central-1","service":"EC2"},{"ip_prefix":"18.216.0.0/14","region":"us-east-2","service":"EC2"},{"ip_prefix":"34.192.0.0/12","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.215.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"177.71.128.0/17","region":"sa-east-1","service":"EC2"},{"ip_prefix":"18.175.0.0/16","region":"eu-west-2","service":"EC2"},{"ip_prefix":"54.208.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.228.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.229.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"18.138.0.0/15","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.95.255.144/28","region":"cn-north-1","service":"EC2"},{"ip_prefix":"3.120.0.0/14","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.9.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.94.248.48/28","region":"sa-east-1","service":"EC2"},{"ip_prefix":"54.242.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"216.182.238.0/23","region":"us-east-1","service":"EC2"},{"ip_prefix":"35.180.0.0/16","region":"eu-west-3","service":"EC2"},{"ip_prefix":"18.228.0.0/16","region":"sa-east-1","service":"EC2"},{"ip_prefix":"52.16.0.0/15","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.95.242.0/24","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"13.52.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"46.137.128.0/18","region":"eu-west-1","service":"EC2"},{"ip_prefix":"52.94.248.176/28","region":"ap-northeast-2","service":"EC2"},{"ip_prefix":"54.234.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"18.188.0.0/16","region":"us-east-2","service":"EC2"},{"ip_prefix":"46.51.128.0/18","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.153.0.0/17","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.24.0.0/14","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.222.0.0/17","region":"us-gov-west-1","service":"EC2"},{"ip_prefix":"52.94.248.224/28","region":"us-gov-west-1","service":"EC2"},{"ip_prefix":"52.95.255.48/28","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.218.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"3.124.0.0/14","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.82.176.0/22","region":"cn-northwest-1","service":"EC2"},{"ip_prefix":"54.183.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.95.255.0/28","region":"sa-east-1","service":"EC2"},{"ip_prefix":"54.176.0.0/15","region":"us-west-1","service":"EC2"},{"ip_prefix":"54.246.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"18.231.0.0/16","region":"sa-east-1","service":"EC2"},{"ip_prefix":"54.252.0.0/16","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"46.137.224.0/19","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.144.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.169.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.66.0.0/16","region":"ap-southeast-2","service":"EC2"},{"ip_prefix":"52.2.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"103.4.8.0/21","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"184.72.64.0/18","region":"us-east-1","service":"EC2"},{"ip_prefix":"18.179.0.0/16","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"46.51.224.0/19","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"54.179.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"54.233.0.0/18","region":"sa-east-1","service":"EC2"},{"ip_prefix":"52.8.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"52.95.247.0/24","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.66.0.0/16","region":"ap-south-1","service":"EC2"},{"ip_prefix":"204.236.192.0/18","region":"us-east-1","service":"EC2"},{"ip_prefix":"54.64.0.0/15","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"176.34.0.0/19","region":"ap-northeast-1","service":"EC2"},{"ip_prefix":"52.95.248.0/24","region":"eu-central-1","service":"EC2"},{"ip_prefix":"3.0.0.0/15","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.77.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.119.205.0/24","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"18.224.0.0/14","region":"us-east-2","service":"EC2"},{"ip_prefix":"52.56.0.0/16","region":"eu-west-2","service":"EC2"},{"ip_prefix":"54.245.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.95.251.0/24","region":"us-east-2","service":"EC2"},{"ip_prefix":"52.4.0.0/14","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.46.184.0/22","region":"eu-central-1","service":"EC2"},{"ip_prefix":"52.67.0.0/16","region":"sa-east-1","service":"EC2"},{"ip_prefix":"18.201.0.0/16","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.151.128.0/17","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"52.81.0.0/16","region":"cn-north-1","service":"EC2"},{"ip_prefix":"13.250.0.0/15","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"3.16.0.0/14","region":"us-east-2","service":"EC2"},{"ip_prefix":"18.130.0.0/16","region":"eu-west-2","service":"EC2"},{"ip_prefix":"52.72.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.82.180.0/22","region":"cn-northwest-1","service":"EC2"},{"ip_prefix":"18.136.0.0/16","region":"ap-southeast-1","service":"EC2"},{"ip_prefix":"50.112.0.0/16","region":"us-west-2","service":"EC2"},{"ip_prefix":"52.95.255.80/28","region":"us-east-1","service":"EC2"},{"ip_prefix":"52.95.250.0/24","region":"ca-central-1","service":"EC2"},{"ip_prefix":"50.19.0.0/16","region":"us-east-1","service":"EC2"},{"ip_prefix":"99.79.0.0/16","region":"ca-central-1","service":"EC2"},{"ip_prefix":"13.57.0.0/16","region":"us-west-1","service":"EC2"},{"ip_prefix":"13.126.0.0/15","region":"ap-south-1","service":"EC2"},{"ip_prefix":"54.172.0.0/15","region":"us-east-1","service":"EC2"},{"ip_prefix":"176.34.64.0/18","region":"eu-west-1","service":"EC2"},{"ip_prefix":"54.233.128.0/17","region":"sa-east-1","service":"EC2"},{"ip_prefix":"205.251.192.0/21","region":"GLOBAL","service":"ROUTE53"},{"ip_prefix":"52.95.110.0/24","region":"GLOBAL","service":"ROUTE53"},{"ip_prefix":"13.124.199.0/24","region":"ap-northeast-2","service":"CLOUDFRONT"},{"ip_prefix":"34.226.14.0/24","region":"us-east-1","service":"CLOUDFRONT"},{"ip_prefix":"52.124.128.0/17","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"54.230.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"54.239.128.0/18","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.82.128.0/19","region":"cn-northwest-1","service":"CLOUDFRONT"},{"ip_prefix":"99.84.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.15.127.128/26","region":"us-east-2","service":"CLOUDFRONT"},{"ip_prefix":"35.158.136.0/24","region":"eu-central-1","service":"CLOUDFRONT"},{"ip_prefix":"52.57.254.0/24","region":"eu-central-1","service":"CLOUDFRONT"},{"ip_prefix":"18.216.170.128/25","region":"us-east-2","service":"CLOUDFRONT"},{"ip_prefix":"13.54.63.128/26","region":"ap-southeast-2","service":"CLOUDFRONT"},{"ip_prefix":"13.59.250.0/26","region":"us-east-2","service":"CLOUDFRONT"},{"ip_prefix":"13.210.67.128/26","region":"ap-southeast-2","service":"CLOUDFRONT"},{"ip_prefix":"35.167.191.128/26","region":"us-west-2","service":"CLOUDFRONT"},{"ip_prefix":"52.47.139.0/24","region":"eu-west-3","service":"CLOUDFRONT"},{"ip_prefix":"52.199.127.192/26","region":"ap-northeast-1","service":"CLOUDFRONT"},{"ip_prefix":"52.212.248.0/26","region":"eu-west-1","service":"CLOUDFRONT"},{"ip_prefix":"205.251.192.0/19","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.66.194.128/26","region":"ap-south-1","service":"CLOUDFRONT"},{"ip_prefix":"54.239.192.0/19","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"70.132.0.0/18","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"13.32.0.0/15","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"13.224.0.0/14","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"13.113.203.0/24","region":"ap-northeast-1","service":"CLOUDFRONT"},{"ip_prefix":"34.195.252.0/24","region":"us-east-1","service":"CLOUDFRONT"},{"ip_prefix":"35.162.63.192/26","region":"us-west-2","service":"CLOUDFRONT"},{"ip_prefix":"34.223.12.224/27","region":"us-west-2","service":"CLOUDFRONT"},{"ip_prefix":"13.35.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"204.246.172.0/23","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"204.246.164.0/22","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.56.127.0/25","region":"eu-west-2","service":"CLOUDFRONT"},{"ip_prefix":"204.246.168.0/22","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"13.228.69.0/24","region":"ap-southeast-1","service":"CLOUDFRONT"},{"ip_prefix":"34.216.51.0/25","region":"us-west-2","service":"CLOUDFRONT"},{"ip_prefix":"71.152.0.0/17","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"216.137.32.0/19","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"205.251.249.0/24","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"99.86.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.46.0.0/18","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.84.0.0/15","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"54.233.255.128/26","region":"sa-east-1","service":"CLOUDFRONT"},{"ip_prefix":"64.252.64.0/18","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.52.191.128/26","region":"us-west-1","service":"CLOUDFRONT"},{"ip_prefix":"204.246.174.0/23","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"64.252.128.0/18","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"205.251.254.0/24","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"143.204.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"205.251.252.0/23","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.78.247.128/26","region":"ap-northeast-2","service":"CLOUDFRONT"},{"ip_prefix":"204.246.176.0/20","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.220.191.0/26","region":"ap-southeast-1","service":"CLOUDFRONT"},{"ip_prefix":"13.249.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"54.240.128.0/18","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"205.251.250.0/23","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"52.222.128.0/17","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"54.182.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"54.192.0.0/16","region":"GLOBAL","service":"CLOUDFRONT"},{"ip_prefix":"34.232.163.208/29","region":"us-east-1","service":"CLOUDFRONT"},{"ip_prefix":"52.47.73.72/29","region":"eu-west-3","service":"CODEBUILD"},{"ip_prefix":"13.55.255.216/29","region":"ap-southeast-2","service":"CODEBUILD"},{"ip_prefix":"52.15.247.208/29","region":"us-east-2","service":"CODEBUILD"},{"ip_prefix":"13.112.191.184/29","region":"ap-northeast-1","service":"CODEBUILD"},{"ip_prefix":"34.250.63.248/29","region":"eu-west-1","service":"CODEBUILD"},{"ip_prefix":"52.221.221.128/29","region":"ap-southeast-1","service":"CODEBUILD"},{"ip_prefix":"13.127.70.136/29","region":"ap-south-1","service":"CODEBUILD"},{"ip_prefix":"52.82.1.0/29","region":"cn-northwest-1","service":"CODEBUILD"},{"ip_prefix":"177.71.207.16/29","region":"sa-east-1","service":"CODEBUILD"},{"ip_prefix":"13.124.145.16/29","region":"ap-northeast-2","service":"CODEBUILD"},{"ip_prefix":"35.157.127.248/29","region":"eu-central-1","service":"CODEBUILD"},{"ip_prefix":"35.182.14.48/29","region":"ca-central-1","service":"CODEBUILD"},{"ip_prefix":"35.176.92.32/29","region":"eu-west-2","service":"CODEBUILD"},{"ip_prefix":"52.43.76.88/29","region":"us-west-2","service":"CODEBUILD"},{"ip_prefix":"18.231.194.8/29","region":"sa-east-1","service":"CODEBUILD"},{"ip_prefix":"52.80.198.136/29","region":"cn-north-1","service":"CODEBUILD"},{"ip_prefix":"13.56.32.200/29","region":"us-west-1","service":"CODEBUILD"},{"ip_prefix":"34.228.4.208/28","region":"us-east-1","service":"CODEBUILD"},{"ip_prefix":"13.248.99.0/24","region":"us-west-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.174.0/24","region":"ca-central-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"13.248.128.0/17","region":"GLOBAL","service":"GLOBALACCELERATOR"},{"ip_prefix":"76.223.0.0/17","region":"GLOBAL","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.160.0/24","region":"ap-south-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"13.248.97.0/24","region":"eu-central-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"13.248.98.0/24","region":"ap-northeast-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.161.0/24","region":"eu-west-3","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.171.0/24","region":"us-east-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.162.0/24","region":"eu-west-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.173.0/24","region":"ap-southeast-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.163.0/24","region":"eu-central-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.166.0/24","region":"us-east-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"75.2.0.0/17","region":"GLOBAL","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.175.0/24","region":"us-east-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.164.0/24","region":"sa-east-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.168.0/24","region":"ap-northeast-2","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.83.128.0/17","region":"GLOBAL","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.167.0/24","region":"us-east-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.156.0/22","region":"GLOBAL","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.172.0/24","region":"us-west-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"13.248.96.0/24","region":"eu-west-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.169.0/24","region":"eu-west-2","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.165.0/24","region":"us-east-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"99.82.170.0/24","region":"ap-northeast-1","service":"GLOBALACCELERATOR"},{"ip_prefix":"13.208.170.0/23","region":"ap-northeast-3","service":"AMAZON"},{"ip_prefix":"13.234.8.0/23","region":"ap-south-1","service":"AMAZON"},{"ip_prefix":"13.251.113.64/26","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"13.251.116.0/23","region":"ap-southeast-1","service":"AMAZON"},{"ip_prefix":"13.52.118.0/23","region":"us-west-1","service":"AMAZON"},{"ip_prefix":"13.53.180.0/23","region":"eu-north-1","service":"AMAZON"},{"ip_prefix":"18.228.246.0/23","region":"sa-east-1","service":"AMAZON"},{"ip_prefix":"3.104.82.0/23","region":"ap-southeast-2","service":"AMAZON"},{"ip_prefix":"3.112.64.0/23","region":"ap-northeast-1","service":"AMAZON"},{"ip_prefix":"3.122.128.0/23","region":"eu-central-1","service":"AMAZON"},{"ip_prefix":"3.17.136.0/23","region":"us-east-2","service":"AMAZON"},{"ip_prefix":"3.8.168.0/23","region":"eu-west-2","service":"AMAZON"},{"ip_prefix":"3.83.168.0/22","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"3.91.171.128/25","region":"us-east-1","service":"AMAZON"},{"ip_prefix":"34.223.24.0/22","region":"us-west-2","service":"AMAZON"},{"ip_prefix":"35.180.244.0/23","region":"eu-west-3","service":"AMAZON"},{"ip_prefix":"54.180.184.0/23","region":"ap-northeast-2","service":"AMAZON"},{"ip_prefix":"63.34.60.0/22","region":"eu-west-1","service":"AMAZON"},{"ip_prefix":"99.79.34.0/23","region":"ca-central-1","service":"AMAZON"},{"ip_prefix":"13.210.2.192/26","region":"ap-southeast-2","service":"AMAZON_CONNECT"},{"ip_prefix":"13.236.8.0/25","region":"ap-southeast-2","service":"AMAZON_CONNECT"},{"ip_prefix":"18.182.96.64/26","region":"ap-northeast-1","service":"AMAZON_CONNECT"},{"ip_prefix":"18.184.2.128/25","region":"eu-central-1","service":"AMAZON_CONNECT"},{"ip_prefix":"18.233.213.128/25","region":"us-east-1","service":"AMAZON_CONNECT"},{"ip_prefix":"18.236.61.0/25","region":"us-west-2","service":"AMAZON_CONNECT"},{"ip_prefix":"35.158.127.64/26","region":"eu-central-1","service":"AMAZON_CONNECT"},{"ip_prefix":"52.55.191.224/27","region":"us-east-1","service":"AMAZON_CONNECT"},{"ip_prefix":"54.190.198.32/28","region":"us-west-2","service":"AMAZON_CONNECT"},{"ip_prefix":"13.250.186.128/27","region":"ap-southeast-1","service":"CLOUD9"},{"ip_prefix":"13.250.186.160/27","region":"ap-southeast-1","service":"CLOUD9"},{"ip_prefix":"18.188.9.0/27","region":"us-east-2","service":"CLOUD9"},{"ip_prefix":"18.188.9.32/27","region":"us-east-2","service":"CLOUD9"},{"ip_prefix":"34.217.141.224/27","region":"us-west-2","service":"CLOUD9"},{"ip_prefix":"34.218.119.32/27","region":"us-west-2","service":"CLOUD9"},{"ip_prefix":"34.245.205.0/27","region":"eu-west-1","service":"CLOUD9"},{"ip_prefix":"34.245.205.64/27","region":"eu-west-1","service":"CLOUD9"},{"ip_prefix":"35.172.155.192/27","region":"us-east-1","service":"CLOUD9"},{"ip_prefix":"35.172.155.96/27","region":"us-east-1","service":"CLOUD9"}],"ipv6_prefixes":[{"ipv6_prefix":"2a05:d07c:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a300::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d000:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafe:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4860::/47","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:daff:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2406:da1a::/36","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf8:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:daf9:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2a05:d050:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2406:daa0:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d000:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2620:107:300f::/64","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07c:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2406:da18::/36","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafa:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2400:6500:ff00::/64","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2a01:578:0:7000::/56","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf9:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"240f:80ff:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4822::/56","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2400:6500:0:7500::/56","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:48b0::/47","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07e:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4840::/47","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:48d2::/47","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d050:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a600::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d07c:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a500::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2400:6500:0:7100::/56","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d050:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2406:dafa:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2a05:d07f:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2406:dafa:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f14::/35","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:7000::/56","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d079:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2400:6500:100:7200::/56","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafa:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf9:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2406:daf9:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d050:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafe:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4820::/47","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07e:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafc:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d000:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d07f:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:eee::/48","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2406:daa0:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:5::/64","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2400:6500:0:7200::/56","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:aa00::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4870::/47","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d079:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2620:108:d000::/44","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2406:daff:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07f:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d000:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d050:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2406:da16::/36","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2406:daa0:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:7200::/56","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"240f:80fe:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07e:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07f:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2a05:d016::/36","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafa:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:da00:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:7100::/56","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafe:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4810::/47","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2600:1f12::/36","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4830::/47","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d079:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d050:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:ab00::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2406:daf8:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d07f:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"240f:80a0:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf9:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d078:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2406:dafc:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafe:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2406:daff:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:da00:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:5000::/36","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:af00::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a100::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2600:1f1c::/36","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:4000::/36","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d012::/36","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2600:9000:ac00::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"240f:80fc:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f11::/36","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"240f:80f9:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:5000::/40","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07c:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2406:daff:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"240f:8014::/36","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"240f:80a0:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d078:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d079:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"240f:80f8:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:3000::/36","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2600:9000:f000::/36","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2804:800:0:7000::/56","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4850::/47","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:48a0::/47","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:fff::/48","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"240f:80fa:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"240f:80f9:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f1e::/36","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2620:108:7000::/44","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a400::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d079:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"240f:80fc:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:48d0::/47","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a800::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d01e::/36","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07a:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2400:6700:ff00::/64","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2403:b300:ff00::/64","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:daa0:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:1f16::/36","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d078:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d07a:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2a05:d07a:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2406:daff:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d078:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf8:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:dafc:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:4000::/40","region":"us-west-2","service":"AMAZON"},{"ipv6_prefix":"2406:dafc:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2406:daf8:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:2000::/36","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2400:6500:0:7400::/56","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:9000:1000::/36","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d07f:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"240f:8018::/36","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:5000::/40","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2a01:578:13::/64","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2406:da00:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:1000::/40","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4880::/47","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2620:107:4007::/64","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07c:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a900::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d07a:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:7400::/56","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2a01:578:0:7100::/56","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fa0:5000::/40","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"240f:8000:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07e:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf8:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2400:6500:0:7000::/56","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafa:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:5000::/40","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2400:6500:100:7100::/56","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:2000::/40","region":"us-gov-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f15::/32","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4800::/47","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d078:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07a:8000::/40","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07e:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2406:da00:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:da14::/36","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:8000::/40","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d078:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d000:2000::/40","region":"eu-west-3","service":"AMAZON"},{"ipv6_prefix":"2600:1ffe:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d07c:c000::/40","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2406:dafc:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:9000:ae00::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d07e:4000::/40","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:c000::/40","region":"us-west-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:ddd::/48","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2406:da1c::/36","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2406:da00:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2406:daa0:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf9:2000::/40","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2804:800:ff00::/64","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d079:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"2406:da00:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:7a00::/56","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffa:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"240f:8000:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:ad00::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d018::/36","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"240f:80ff:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:48c0::/47","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2a05:d01c::/36","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2406:dafc:8000::/40","region":"ap-southeast-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff9:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d000:6000::/40","region":"eu-north-1","service":"AMAZON"},{"ipv6_prefix":"240f:80f8:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a200::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:7800::/56","region":"ca-central-1","service":"AMAZON"},{"ipv6_prefix":"2a01:578:0:7200::/56","region":"eu-west-2","service":"AMAZON"},{"ipv6_prefix":"2a05:d014::/36","region":"eu-central-1","service":"AMAZON"},{"ipv6_prefix":"2406:daf8:4000::/40","region":"ap-northeast-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafe:c000::/40","region":"ap-southeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:1f18::/33","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ff8:e000::/40","region":"sa-east-1","service":"AMAZON"},{"ipv6_prefix":"240f:80fe:8000::/40","region":"cn-north-1","service":"AMAZON"},{"ipv6_prefix":"2620:107:4000:7700::/56","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"2406:daa0:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2406:dafe:a000::/40","region":"ap-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:5000::/40","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2406:da12::/36","region":"ap-northeast-2","service":"AMAZON"},{"ipv6_prefix":"2600:1f01:4890::/47","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2406:daff:6000::/40","region":"ap-northeast-3","service":"AMAZON"},{"ipv6_prefix":"2600:1f00:5000::/40","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2406:da00:ff00::/64","region":"us-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:9000:5300::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2600:9000:a700::/40","region":"GLOBAL","service":"AMAZON"},{"ipv6_prefix":"2a05:d07a:e000::/40","region":"me-south-1","service":"AMAZON"},{"ipv6_prefix":"2600:1ffc:5000::/40","region":"us-gov-east-1","service":"AMAZON"},{"ipv6_prefix":"2600:1fff:6000::/40","region":"us-east-2","service":"AMAZON"},{"ipv6_prefix":"240f:80fa:4000::/40","region":"cn-northwest-1","service":"AMAZON"},{"ipv6_prefix":"2a01:578:3::/64","region":"eu-west-1","service":"AMAZON"},{"ipv6_prefix":"2804:800:ff00::b147:cf80/122","region":"sa-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2400:6700:ff00::36f8:dc00/122","region":"ap-northeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2403:b300:ff00::36fc:4f80/122","region":"ap-southeast-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da00:ff00::36f3:1fc0/122","region":"us-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da14:fff:f800::/53","region":"ap-northeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2400:6500:ff00::36ff:fec0/122","region":"ap-southeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2a01:578:3::36e4:1000/122","region":"eu-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f14:fff:f800::/53","region":"us-west-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f14:7ff:f800::/53","region":"us-west-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da1c:7ff:f800::/53","region":"ap-southeast-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f18:7fff:f800::/53","region":"us-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f18:3fff:f800::/53","region":"us-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2804:800:ff00::36e8:2840/122","region":"sa-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2620:107:300f::36f1:2040/122","region":"us-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2620:108:700f::36f4:34c0/122","region":"us-west-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2620:107:300f::36b7:ff80/122","region":"us-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2403:b300:ff00::36fc:fec0/122","region":"ap-southeast-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2a05:d018:7ff:f800::/53","region":"eu-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da00:ff00::6b17:ff00/122","region":"us-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2400:6700:ff00::36fa:fdc0/122","region":"ap-northeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da18:fff:f800::/53","region":"ap-southeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2620:108:700f::36f5:a800/122","region":"us-west-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f1c:7ff:f800::/53","region":"us-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da1c:fff:f800::/53","region":"ap-southeast-2","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f1e:fff:f800::/53","region":"sa-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f1c:fff:f800::/53","region":"us-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da14:7ff:f800::/53","region":"ap-northeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2a05:d018:fff:f800::/53","region":"eu-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2400:6500:ff00::36fb:1f80/122","region":"ap-southeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:da18:7ff:f800::/53","region":"ap-southeast-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2600:1f1e:7ff:f800::/53","region":"sa-east-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2a01:578:3::b022:9fc0/122","region":"eu-west-1","service":"ROUTE53_HEALTHCHECKS"},{"ipv6_prefix":"2406:daf8:c000::/40","region":"ap-southeast-2","service":"S3"},{"ipv6_prefix":"2406:daf9:6000::/40","region":"ap-northeast-3","service":"S3"},{"ipv6_prefix":"2a05:d050:2000::/40","region":"eu-west-3","service":"S3"},{"ipv6_prefix":"2406:daa0:8000::/40","region":"ap-southeast-1","service":"S3"},{"ipv6_prefix":"2406:dafa:2000::/40","region":"ap-northeast-2","service":"S3"},{"ipv6_prefix":"2406:daf9:8000::/40","region":"ap-southeast-1","service":"S3"},{"ipv6_prefix":"2a05:d050:e000::/40","region":"me-south-1","service":"S3"},{"ipv6_prefix":"2a05:d050:c000::/40","region":"eu-west-2","service":"S3"},{"ipv6_prefix":"2406:dafa:6000::/40","region":"ap-northeast-3","service":"S3"},{"ipv6_prefix":"2406:dafa:a000::/40","region":"ap-south-1","service":"S3"},{"ipv6_prefix":"2a05:d079:c000::/40","region":"eu-west-2","service":"S3"},{"ipv6_prefix":"2406:dafa:4000::/40","region":"ap-northeast-1","service":"S3"},{"ipv6_prefix":"2406:daf9:a000::/40","region":"ap-south-1","service":"S3"},{"ipv6_prefix":"2600:1ffa:2000::/40","region":"us-gov-west-1","service":"S3"},{"ipv6_prefix":"2600:1ffa:6000::/40","region":"us-east-2","service":"S3"},{"ipv6_prefix":"2406:daf9:4000::/40","region":"ap-northeast-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:4000::/40","region":"us-west-2","service":"S3"},{"ipv6_prefix":"2a05:d050:8000::/40","region":"eu-west-1","service":"S3"},{"ipv6_prefix":"2406:daa0:6000::/40","region":"ap-northeast-3","service":"S3"},{"ipv6_prefix":"2600:1ff8:1000::/40","region":"ca-central-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:c000::/40","region":"us-west-1","service":"S3"},{"ipv6_prefix":"2a05:d079:e000::/40","region":"me-south-1","service":"S3"},{"ipv6_prefix":"2600:1fa0:4000::/40","region":"us-west-2","service":"S3"},{"ipv6_prefix":"2a05:d050:4000::/40","region":"eu-central-1","service":"S3"},{"ipv6_prefix":"2600:1fa0:8000::/40","region":"us-east-1","service":"S3"},{"ipv6_prefix":"2406:daa0:c000::/40","region":"ap-southeast-2","service":"S3"},{"ipv6_prefix":"2600:1ffa:c000::/40","region":"us-west-1","service":"S3"},{"ipv6_prefix":"2406:dafa:c000::/40","region":"ap-southeast-2","service":"S3"},{"ipv6_prefix":"2600:1fa0:e000::/40","region":"sa-east-1","service":"S3"},{"ipv6_prefix":"2a05:d079:8000::/40","region":"eu-west-1","service":"S3"},{"ipv6_prefix":"2406:daf8:8000::/40","region":"ap-southeast-1","service":"S3"},{"ipv6_prefix":"2600:1ff8:4000::/40","region":"us-west-2","service":"S3"},{"ipv6_prefix":"240f:80a0:8000::/40","region":"cn-north-1","service":"S3"},{"ipv6_prefix":"2406:daf9:c000::/40","region":"ap-southeast-2","service":"S3"},{"ipv6_prefix":"2a05:d078:2000::/40","region":"eu-west-3","service":"S3"},{"ipv6_prefix":"2600:1ff8:5000::/36","region":"us-gov-east-1","service":"S3"},{"ipv6_prefix":"240f:80f9:8000::/40","region":"cn-north-1","service":"S3"},{"ipv6_prefix":"240f:80a0:4000::/40","region":"cn-northwest-1","service":"S3"},{"ipv6_prefix":"2600:1ffa:4000::/40","region":"us-west-2","service":"S3"},{"ipv6_prefix":"2a05:d078:8000::/40","region":"eu-west-1","service":"S3"},{"ipv6_prefix":"2a05:d079:4000::/40","region":"eu-central-1","service":"S3"},{"ipv6_prefix":"240f:80f8:4000::/40","region":"cn-northwest-1","service":"S3"},{"ipv6_prefix":"240f:80fa:8000::/40","region":"cn-north-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:e000::/40","region":"sa-east-1","service":"S3"},{"ipv6_prefix":"240f:80f9:4000::/40","region":"cn-northwest-1","service":"S3"},{"ipv6_prefix":"2a05:d079:2000::/40","region":"eu-west-3","service":"S3"},{"ipv6_prefix":"2600:1ffa:8000::/40","region":"us-east-1","service":"S3"},{"ipv6_prefix":"2600:1fa0:6000::/40","region":"us-east-2","service":"S3"},{"ipv6_prefix":"2a05:d07a:c000::/40","region":"eu-west-2","service":"S3"},{"ipv6_prefix":"2600:1fa0:c000::/40","region":"us-west-1","service":"S3"},{"ipv6_prefix":"2406:daa0:2000::/40","region":"ap-northeast-2","service":"S3"},{"ipv6_prefix":"2a05:d078:c000::/40","region":"eu-west-2","service":"S3"},{"ipv6_prefix":"2a05:d07a:2000::/40","region":"eu-west-3","service":"S3"},{"ipv6_prefix":"2a05:d07a:6000::/40","region":"eu-north-1","service":"S3"},{"ipv6_prefix":"2600:1fa0:2000::/40","region":"us-gov-west-1","service":"S3"},{"ipv6_prefix":"2a05:d078:e000::/40","region":"me-south-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:1000::/40","region":"ca-central-1","service":"S3"},{"ipv6_prefix":"2406:daf8:2000::/40","region":"ap-northeast-2","service":"S3"},{"ipv6_prefix":"2600:1ff8:6000::/40","region":"us-east-2","service":"S3"},{"ipv6_prefix":"2406:daf8:a000::/40","region":"ap-south-1","service":"S3"},{"ipv6_prefix":"2600:1ffa:1000::/40","region":"ca-central-1","service":"S3"},{"ipv6_prefix":"2600:1ffa:5000::/40","region":"us-gov-east-1","service":"S3"},{"ipv6_prefix":"2600:1fa0:1000::/40","region":"ca-central-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:2000::/40","region":"us-gov-west-1","service":"S3"},{"ipv6_prefix":"2a05:d07a:4000::/40","region":"eu-central-1","service":"S3"},{"ipv6_prefix":"2600:1fa0:5000::/40","region":"us-gov-east-1","service":"S3"},{"ipv6_prefix":"2406:daf8:6000::/40","region":"ap-northeast-3","service":"S3"},{"ipv6_prefix":"2406:dafa:8000::/40","region":"ap-southeast-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:5000::/40","region":"us-gov-east-1","service":"S3"},{"ipv6_prefix":"2600:1ff8:8000::/40","region":"us-east-1","service":"S3"},{"ipv6_prefix":"2600:1ff8:2000::/40","region":"us-gov-west-1","service":"S3"},{"ipv6_prefix":"2a05:d078:4000::/40","region":"eu-central-1","service":"S3"},{"ipv6_prefix":"2a05:d07a:8000::/40","region":"eu-west-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:8000::/40","region":"us-east-1","service":"S3"},{"ipv6_prefix":"2a05:d078:6000::/40","region":"eu-north-1","service":"S3"},{"ipv6_prefix":"2600:1ff8:c000::/40","region":"us-west-1","service":"S3"},{"ipv6_prefix":"2406:daa0:4000::/40","region":"ap-northeast-1","service":"S3"},{"ipv6_prefix":"2406:daf9:2000::/40","region":"ap-northeast-2","service":"S3"},{"ipv6_prefix":"2a05:d079:6000::/40","region":"eu-north-1","service":"S3"},{"ipv6_prefix":"2600:1ffa:e000::/40","region":"sa-east-1","service":"S3"},{"ipv6_prefix":"2600:1ff9:6000::/40","region":"us-east-2","service":"S3"},{"ipv6_prefix":"240f:80f8:8000::/40","region":"cn-north-1","service":"S3"},{"ipv6_prefix":"2406:daf8:4000::/40","region":"ap-northeast-1","service":"S3"},{"ipv6_prefix":"2600:1ff8:e000::/40","region":"sa-east-1","service":"S3"},{"ipv6_prefix":"2406:daa0:a000::/40","region":"ap-south-1","service":"S3"},{"ipv6_prefix":"2a05:d07a:e000::/40","region":"me-south-1","service":"S3"},{"ipv6_prefix":"240f:80fa:4000::/40","region":"cn-northwest-1","service":"S3"},{"ipv6_prefix":"2a05:d000:8000::/40","region":"eu-west-1","service":"EC2"},{"ipv6_prefix":"2406:daff:8000::/40","region":"ap-southeast-1","service":"EC2"},{"ipv6_prefix":"2406:da1a::/36","region":"ap-south-1","service":"EC2"},{"ipv6_prefix":"2a05:d000:4000::/40","region":"eu-central-1","service":"EC2"},{"ipv6_prefix":"2620:107:300f::/64","region":"us-west-1","service":"EC2"},{"ipv6_prefix":"2406:da18::/36","region":"ap-southeast-1","service":"EC2"},{"ipv6_prefix":"2400:6500:ff00::/64","region":"ap-southeast-1","service":"EC2"},{"ipv6_prefix":"240f:80ff:4000::/40","region":"cn-northwest-1","service":"EC2"},{"ipv6_prefix":"2a05:d07f:c000::/40","region":"eu-west-2","service":"EC2"},{"ipv6_prefix":"2600:1f14::/35","region":"us-west-2","service":"EC2"},{"ipv6_prefix":"2600:1f00:e000::/40","region":"sa-east-1","service":"EC2"},{"ipv6_prefix":"2a05:d000:c000::/40","region":"eu-west-2","service":"EC2"},{"ipv6_prefix":"2a05:d07f:e000::/40","region":"me-south-1","service":"EC2"},{"ipv6_prefix":"2600:1fff:1000::/40","region":"ca-central-1","service":"EC2"},{"ipv6_prefix":"2600:1fff:4000::/40","region":"us-west-2","service":"EC2"},{"ipv6_prefix":"2406:daff:4000::/40","region":"ap-northeast-1","service":"EC2"},{"ipv6_prefix":"2a05:d07f:4000::/40","region":"eu-central-1","service":"EC2"},{"ipv6_prefix":"2600:1f00:1000::/40","region":"ca-central-1","service":"EC2"},{"ipv6_prefix":"2a05:d000:e000::/40","region":"me-south-1","service":"EC2"},{"ipv6_prefix":"2406:da16::/36","region":"ap-northeast-3","service":"EC2"},{"ipv6_prefix":"2a05:d07f:2000::/40","region":"eu-west-3","service":"EC2"},{"ipv6_prefix":"2a05:d016::/36","region":"eu-north-1","service":"EC2"},{"ipv6_prefix":"2406:da00:8000::/40","region":"ap-southeast-1","service":"EC2"},{"ipv6_prefix":"2600:1f00:c000::/40","region":"us-west-1","service":"EC2"},{"ipv6_prefix":"2600:1f12::/36","region":"us-gov-west-1","service":"EC2"},{"ipv6_prefix":"2a05:d07f:6000::/40","region":"eu-north-1","service":"EC2"},{"ipv6_prefix":"2600:1fff:e000::/40","region":"sa-east-1","service":"EC2"},{"ipv6_prefix":"2406:daff:c000::/40","region":"ap-southeast-2","service":"EC2"},{"ipv6_prefix":"2406:da00:2000::/40","region":"ap-northeast-2","service":"EC2"},{"ipv6_prefix":"2600:1fff:8000::/40","region":"us-east-1","service":"EC2"},{"ipv6_prefix":"2600:1f1c::/36","region":"us-west-1","service":"EC2"},{"ipv6_prefix":"2a05:d012::/36","region":"eu-west-3","service":"EC2"},{"ipv6_prefix":"2600:1f00:6000::/40","region":"us-east-2","service":"EC2"},{"ipv6_prefix":"2620:108:700f::/64","region":"us-west-2","service":"EC2"},{"ipv6_prefix":"2600:1f11::/36","region":"ca-central-1","service":"EC2"},{"ipv6_prefix":"2406:daff:2000::/40","region":"ap-northeast-2","service":"EC2"},{"ipv6_prefix":"240f:8014::/36","region":"cn-northwest-1","service":"EC2"},{"ipv6_prefix":"2600:1f00:8000::/40","region":"us-east-1","service":"EC2"},{"ipv6_prefix":"2600:1f1e::/36","region":"sa-east-1","service":"EC2"},{"ipv6_prefix":"2a05:d01e::/36","region":"me-south-1","service":"EC2"},{"ipv6_prefix":"2600:1fff:c000::/40","region":"us-west-1","service":"EC2"},{"ipv6_prefix":"2600:1fff:2000::/40","region":"us-gov-west-1","service":"EC2"},{"ipv6_prefix":"2400:6700:ff00::/64","region":"ap-northeast-1","service":"EC2"},{"ipv6_prefix":"2403:b300:ff00::/64","region":"ap-southeast-2","service":"EC2"},{"ipv6_prefix":"2600:1f16::/36","region":"us-east-2","service":"EC2"},{"ipv6_prefix":"2406:daff:a000::/40","region":"ap-south-1","service":"EC2"},{"ipv6_prefix":"2600:1f00:4000::/40","region":"us-west-2","service":"EC2"},{"ipv6_prefix":"2600:1f00:2000::/40","region":"us-gov-west-1","service":"EC2"},{"ipv6_prefix":"2a05:d07f:8000::/40","region":"eu-west-1","service":"EC2"},{"ipv6_prefix":"240f:8018::/36","region":"cn-north-1","service":"EC2"},{"ipv6_prefix":"2a01:578:13::/64","region":"eu-central-1","service":"EC2"},{"ipv6_prefix":"2406:da00:a000::/40","region":"ap-south-1","service":"EC2"},{"ipv6_prefix":"2620:107:4007::/64","region":"us-east-1","service":"EC2"},{"ipv6_prefix":"240f:8000:4000::/40","region":"cn-northwest-1","service":"EC2"},{"ipv6_prefix":"2600:1f15::/32","region":"us-gov-east-1","service":"EC2"},{"ipv6_prefix":"2406:da00:c000::/40","region":"ap-southeast-2","service":"EC2"},{"ipv6_prefix":"2406:da14::/36","region":"ap-northeast-1","service":"EC2"},{"ipv6_prefix":"2a05:d000:2000::/40","region":"eu-west-3","service":"EC2"},{"ipv6_prefix":"2620:108:d00f::/64","region":"us-gov-west-1","service":"EC2"},{"ipv6_prefix":"2406:da1c::/36","region":"ap-southeast-2","service":"EC2"},{"ipv6_prefix":"2406:da00:6000::/40","region":"ap-northeast-3","service":"EC2"},{"ipv6_prefix":"2804:800:ff00::/64","region":"sa-east-1","service":"EC2"},{"ipv6_prefix":"2406:da00:4000::/40","region":"ap-northeast-1","service":"EC2"},{"ipv6_prefix":"240f:8000:8000::/40","region":"cn-north-1","service":"EC2"},{"ipv6_prefix":"2a05:d018::/36","region":"eu-west-1","service":"EC2"},{"ipv6_prefix":"240f:80ff:8000::/40","region":"cn-north-1","service":"EC2"},{"ipv6_prefix":"2a05:d01c::/36","region":"eu-west-2","service":"EC2"},{"ipv6_prefix":"2a05:d000:6000::/40","region":"eu-north-1","service":"EC2"},{"ipv6_prefix":"2a05:d014::/36","region":"eu-central-1","service":"EC2"},{"ipv6_prefix":"2600:1f18::/33","region":"us-east-1","service":"EC2"},{"ipv6_prefix":"2600:1fff:5000::/40","region":"us-gov-east-1","service":"EC2"},{"ipv6_prefix":"2406:da12::/36","region":"ap-northeast-2","service":"EC2"},{"ipv6_prefix":"2406:daff:6000::/40","region":"ap-northeast-3","service":"EC2"},{"ipv6_prefix":"2600:1f00:5000::/40","region":"us-gov-east-1","service":"EC2"},{"ipv6_prefix":"2406:da00:ff00::/64","region":"us-east-1","service":"EC2"},{"ipv6_prefix":"2600:1fff:6000::/40","region":"us-east-2","service":"EC2"},{"ipv6_prefix":"2a01:578:3::/64","region":"eu-west-1","service":"EC2"},{"ipv6_prefix":"2600:9000:eee::/48","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:4000::/36","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:3000::/36","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:f000::/36","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:fff::/48","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:2000::/36","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:1000::/36","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:ddd::/48","region":"GLOBAL","service":"CLOUDFRONT"},{"ipv6_prefix":"2600:9000:5300::/40","region":"GLOBAL","service":"CLOUDFRONT"}]}`
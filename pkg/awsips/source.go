@@ -0,0 +1,163 @@
+package awsips
+
+//go:generate go run gen_snapshot.go -o snapshot.go https://ip-ranges.amazonaws.com/ip-ranges.json
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// Sources of IP ranges other than the getter's URL.
+const (
+	SourceCache    = "cache"
+	SourceSnapshot = "snapshot"
+)
+
+// snapshotMaxShrinkPercent is the MaxShrinkPercent of rules generated from the
+// compiled-in snapshot. Removing any rule exceeds it.
+const snapshotMaxShrinkPercent = math.SmallestNonzeroFloat64
+
+// SnapshotOptions returns the options to apply rules generated from IP ranges
+// from source with. The compiled-in snapshot may be far older than the live
+// file, so no rules are removed with it: a target which would lose any rules
+// is left unchanged and an error is returned.
+func SnapshotOptions(source string, opts rule.Options) rule.Options {
+	if source != SourceSnapshot {
+		return opts
+	}
+
+	log.Print("WARNING: rules are generated from the compiled-in IP ranges snapshot; no rules will be removed")
+	opts.MaxShrinkPercent = snapshotMaxShrinkPercent
+	return opts
+}
+
+// fetched is the result of fetching an IP ranges file.
+type fetched struct {
+	body         []byte
+	etag         string
+	lastModified string
+	notModified  bool
+}
+
+// fetch gets the raw IP ranges file from the getter's URL.
+func (g *IPRangesGetter) fetch(cached *CacheEntry) (*fetched, error) {
+	u, err := url.Parse(g.url)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return g.fetchHTTP(cached)
+	case "file":
+		return fetchFile(u)
+	case "s3":
+		return g.fetchS3(u, cached)
+	default:
+		return nil, fmt.Errorf("unsupported IP ranges URL: %s", g.url)
+	}
+}
+
+// fetchHTTP downloads an http(s):// URL.
+func (g *IPRangesGetter) fetchHTTP(cached *CacheEntry) (*fetched, error) {
+	req, err := http.NewRequest(http.MethodGet, g.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	res, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified {
+		return &fetched{notModified: true}, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Got status: %d", res.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fetched{
+		body:         body,
+		etag:         res.Header.Get("ETag"),
+		lastModified: res.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// fetchFile reads a file:// URL. Both file:///absolute/path and
+// file://relative/path are accepted.
+func fetchFile(u *url.URL) (*fetched, error) {
+	body, err := ioutil.ReadFile(filepath.FromSlash(u.Host + u.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	return &fetched{body: body}, nil
+}
+
+// fetchS3 downloads an s3://bucket/key URL.
+func (g *IPRangesGetter) fetchS3(u *url.URL, cached *CacheEntry) (*fetched, error) {
+	if g.s3Client == nil {
+		g.s3Client = s3.New(session.New())
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(u.Host),
+		Key:    aws.String(strings.TrimPrefix(u.Path, "/")),
+	}
+	if cached != nil && cached.ETag != "" {
+		input.IfNoneMatch = aws.String(cached.ETag)
+	}
+
+	res, err := g.s3Client.GetObject(input)
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == http.StatusNotModified {
+		return &fetched{notModified: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &fetched{
+		body: body,
+		etag: aws.StringValue(res.ETag),
+	}
+	if res.LastModified != nil {
+		f.lastModified = res.LastModified.UTC().Format(http.TimeFormat)
+	}
+
+	return f, nil
+}
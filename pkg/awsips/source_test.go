@@ -0,0 +1,144 @@
+package awsips
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+var s3Expect = []string{
+	"52.95.174.0/24",
+	"52.95.172.0/23",
+}
+
+func TestFileSource(t *testing.T) {
+	abs, err := filepath.Abs("testdata/ip-ranges.json")
+	assert.NoError(t, err)
+
+	for _, uri := range []string{"file://testdata/ip-ranges.json", "file://" + filepath.ToSlash(abs)} {
+		getter := NewIPRangesGetter(uri, []string{"me-south-1"})
+		result, err := getter.GetService("S3")
+
+		assert.NoError(t, err)
+		assert.Equal(t, s3Expect, result)
+		assert.Equal(t, uri, getter.Source())
+	}
+}
+
+func TestS3Source(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/ip-ranges.json")
+	assert.NoError(t, err)
+
+	client := &mockS3Client{
+		etag: `"2182bff9048fe44d4c1d9b37903fc632"`,
+		data: data,
+	}
+	store := NewMemoryStore()
+
+	first := NewIPRangesGetter("s3://bucket/path/ip-ranges.json", []string{"me-south-1"}, WithS3Client(client), WithCache(store))
+	result, err := first.GetService("S3")
+	assert.NoError(t, err)
+	assert.Equal(t, s3Expect, result)
+	assert.False(t, first.Unchanged())
+
+	second := NewIPRangesGetter("s3://bucket/path/ip-ranges.json", []string{"me-south-1"}, WithS3Client(client), WithCache(store))
+	result, err = second.GetService("S3")
+	assert.NoError(t, err)
+	assert.Equal(t, s3Expect, result)
+	assert.True(t, second.Unchanged())
+
+	assert.Equal(t, []string{"bucket/path/ip-ranges.json", "bucket/path/ip-ranges.json"}, client.calls)
+	assert.Equal(t, 1, client.downloads)
+}
+
+func TestUnsupportedSource(t *testing.T) {
+	_, err := NewIPRangesGetter("ftp://example.com/ip-ranges.json", nil).Get()
+	assert.Error(t, err)
+}
+
+func TestSnapshotFallback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "you broke the internet", http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	// The snapshot is regenerated from the live IP ranges file, so it is
+	// compared with itself rather than with testdata.
+	expect, err := parse([]byte(snapshot))
+	assert.NoError(t, err)
+
+	getter := NewIPRangesGetter(ts.URL, []string{"me-south-1"}, WithSnapshotFallback())
+	ranges, err := getter.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, expect, ranges)
+	assert.Equal(t, SourceSnapshot, getter.Source())
+
+	store := NewMemoryStore()
+	assert.NoError(t, store.Save(ts.URL, &CacheEntry{
+		Ranges: &IPRanges{
+			SyncToken: "1549989080",
+			Prefixes: []Prefix{
+				{IPPrefix: "52.95.174.0/24", Region: "me-south-1", Service: "S3"},
+			},
+		},
+	}))
+
+	getter = NewIPRangesGetter(ts.URL, []string{"me-south-1"}, WithSnapshotFallback(), WithCache(store))
+	result, err := getter.GetService("S3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"52.95.174.0/24"}, result)
+	assert.Equal(t, SourceCache, getter.Source())
+}
+
+func TestSnapshotOptions(t *testing.T) {
+	opts := rule.Options{Owner: "team-payments", MaxShrinkPercent: 50}
+	assert.Equal(t, opts, SnapshotOptions("https://example.com/ip-ranges.json", opts))
+	assert.Equal(t, opts, SnapshotOptions(SourceCache, opts))
+
+	// Rules from the snapshot never remove any rules.
+	for _, maxPercent := range []float64{0, 50} {
+		opts.MaxShrinkPercent = maxPercent
+		guarded := SnapshotOptions(SourceSnapshot, opts)
+		assert.Equal(t, "team-payments", guarded.Owner)
+
+		plan := &rule.Plan{Remove: []rule.Entry{{CIDR: "52.95.174.0/24"}}, Owned: 1000}
+		assert.Error(t, plan.CheckShrink("sg-1", guarded.MaxShrinkPercent))
+
+		plan = &rule.Plan{Add: []rule.Entry{{CIDR: "52.95.174.0/24"}}, Owned: 1000}
+		assert.NoError(t, plan.CheckShrink("sg-1", guarded.MaxShrinkPercent))
+	}
+}
+
+type mockS3Client struct {
+	s3iface.S3API
+
+	etag string
+	data []byte
+
+	calls     []string
+	downloads int
+}
+
+func (m *mockS3Client) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	m.calls = append(m.calls, aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key))
+
+	if aws.StringValue(input.IfNoneMatch) == m.etag {
+		return nil, awserr.NewRequestFailure(awserr.New("NotModified", "Not Modified", nil), http.StatusNotModified, "")
+	}
+
+	m.downloads++
+	return &s3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader(m.data)),
+		ETag: aws.String(m.etag),
+	}, nil
+}
@@ -0,0 +1,384 @@
+// Package config loads declarative configurations of named rule sets, and the
+// targets each rule set is applied to. A configuration replaces the rules and
+// targets embedded in lambda events, so it can be reviewed and versioned on
+// its own, and is written in YAML or JSON.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Config is a set of named rule sets, and the bindings of rule sets to
+// targets.
+type Config struct {
+	// IPRanges configures the IP ranges file for awsService sources.
+	IPRanges awsips.Config `json:"ipRanges"`
+
+	// RuleSets are named lists of DNS names, AWS services and static
+	// CIDRs.
+	RuleSets map[string][]source.Source `json:"ruleSets"`
+
+	// Bindings apply rule sets to targets.
+	Bindings []Binding `json:"bindings"`
+}
+
+// Binding applies the union of one or more rule sets to targets.
+type Binding struct {
+	// Name identifies the binding in logs, and selects it in events.
+	Name string `json:"name"`
+
+	// RuleSets are the names of the rule sets to apply.
+	RuleSets []string `json:"ruleSets"`
+
+	// SecurityGroupTags selects security groups by tag, in addition to the
+	// security groups listed by ID. A security group is selected if it has
+	// every tag. An empty value matches any value of the tag.
+	SecurityGroupTags map[string]string `json:"securityGroupTags,omitempty"`
+
+	// Targets are the security groups, and other resources such as prefix
+	// lists, to apply the rule sets to.
+	rule.Targets
+
+	// Options configure how rules are applied to the targets.
+	rule.Options
+}
+
+// Job is a binding whose rules are resolved and whose targets are built.
+type Job struct {
+	Binding string
+	Rules   []rule.Rule
+	Targets []rule.Target
+	Options rule.Options
+}
+
+// Parse decodes a configuration and validates it. name is the file name or
+// URL the configuration was read from. The data is decoded as YAML if the
+// name ends in .yaml or .yml, or if the name does not end in .json and the
+// data is not a JSON object.
+func Parse(data []byte, name string) (*Config, error) {
+	ext := strings.ToLower(path.Ext(name))
+	isJSON := ext == ".json" || (ext != ".yaml" && ext != ".yml" && bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")))
+
+	if !isJSON {
+		converted, err := yamlToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		data = converted
+	}
+
+	config := &Config{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(config); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	return config, nil
+}
+
+// yamlToJSON converts a YAML document to JSON, so configurations in either
+// format are decoded with the same struct tags.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonValue(doc))
+}
+
+// jsonValue converts the mappings in a decoded YAML value, whose keys may be
+// of any type, to JSON objects.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return object
+	case []interface{}:
+		for i := range v {
+			v[i] = jsonValue(v[i])
+		}
+		return v
+	}
+
+	return value
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *Config) Validate() error {
+	if len(c.Bindings) == 0 {
+		return errors.New("no bindings are configured")
+	}
+
+	for name, sources := range c.RuleSets {
+		if len(sources) == 0 {
+			return fmt.Errorf("rule set %s has no sources", name)
+		}
+		for i := range sources {
+			if err := sources[i].Validate(); err != nil {
+				return fmt.Errorf("rule set %s: %v", name, err)
+			}
+		}
+	}
+
+	names := make(map[string]bool)
+	for i := range c.Bindings {
+		b := &c.Bindings[i]
+		if b.Name == "" {
+			return errors.New("a binding has no name")
+		}
+		if names[b.Name] {
+			return fmt.Errorf("duplicate binding %s", b.Name)
+		}
+		names[b.Name] = true
+
+		if err := b.validate(c.RuleSets); err != nil {
+			return fmt.Errorf("binding %s: %v", b.Name, err)
+		}
+	}
+
+	return c.checkOwners()
+}
+
+// checkOwners returns an error if two bindings with the same owner could
+// apply rules to the same target, since each would remove the other's rules.
+// Every binding is checked, not only those selected for an invocation, as
+// invocations which select different bindings would otherwise still conflict.
+func (c *Config) checkOwners() error {
+	for i := range c.Bindings {
+		for j := i + 1; j < len(c.Bindings); j++ {
+			a, b := &c.Bindings[i], &c.Bindings[j]
+			if a.Owner != b.Owner {
+				continue
+			}
+			if target := sharedTarget(a, b); target != "" {
+				return fmt.Errorf("bindings %s and %s both apply rules to %s with owner %q", a.Name, b.Name, target, a.Owner)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sharedTarget describes a target which both bindings could apply rules to,
+// or returns an empty string if there is none. Security groups selected by tag
+// are only known when the bindings are applied, so a binding which selects by
+// tag could share any security group listed by the other binding, and any
+// security group selected by tags which do not have different values.
+func sharedTarget(a, b *Binding) string {
+	targets := make(map[string]bool)
+	for _, target := range a.Build(rule.Clients{}) {
+		targets[target.String()] = true
+	}
+	for _, target := range b.Build(rule.Clients{}) {
+		if targets[target.String()] {
+			return target.String()
+		}
+	}
+
+	if len(a.SecurityGroupTags) > 0 && len(b.SecurityGroupTags) > 0 && tagsOverlap(a.SecurityGroupTags, b.SecurityGroupTags) {
+		return fmt.Sprintf("security groups with tags %v and %v", a.SecurityGroupTags, b.SecurityGroupTags)
+	}
+	if len(a.SecurityGroupTags) > 0 && len(b.SecurityGroups) > 0 {
+		return fmt.Sprintf("security groups with tags %v", a.SecurityGroupTags)
+	}
+	if len(b.SecurityGroupTags) > 0 && len(a.SecurityGroups) > 0 {
+		return fmt.Sprintf("security groups with tags %v", b.SecurityGroupTags)
+	}
+
+	return ""
+}
+
+// tagsOverlap returns a boolean for whether a security group could have both
+// sets of tags, which is the case unless a tag has different values.
+func tagsOverlap(a, b map[string]string) bool {
+	for key, value := range a {
+		other, ok := b[key]
+		if ok && value != "" && other != "" && value != other {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validate returns an error if the binding is invalid.
+func (b *Binding) validate(ruleSets map[string][]source.Source) error {
+	if len(b.RuleSets) == 0 {
+		return errors.New("no rule sets")
+	}
+	for _, name := range b.RuleSets {
+		if _, ok := ruleSets[name]; !ok {
+			return fmt.Errorf("undefined rule set %s", name)
+		}
+	}
+
+	if err := b.Options.Validate(); err != nil {
+		return err
+	}
+
+	hasDNS := false
+	for _, name := range b.RuleSets {
+		hasDNS = hasDNS || source.HasDNS(ruleSets[name])
+	}
+	if err := b.ValidateDomainTargets(hasDNS, b.Options); err != nil {
+		return err
+	}
+
+	if len(b.SecurityGroupTags) == 0 && len(b.Build(rule.Clients{})) == 0 {
+		return errors.New("no targets")
+	}
+	for key := range b.SecurityGroupTags {
+		if key == "" {
+			return errors.New("empty security group tag key")
+		}
+	}
+	for i := range b.NetworkACLs {
+		if err := b.NetworkACLs[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Select returns the bindings with the given names, or every binding if no
+// names are given.
+func (c *Config) Select(names []string) ([]Binding, error) {
+	if len(names) == 0 {
+		return c.Bindings, nil
+	}
+
+	bindings := make([]Binding, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, b := range c.Bindings {
+			if b.Name == name {
+				bindings = append(bindings, b)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("undefined binding %s", name)
+		}
+	}
+
+	return bindings, nil
+}
+
+// Jobs resolves the rule sets of the bindings, and builds their targets.
+// Security groups selected by tag are looked up with clients.EC2. Every rule
+// set is resolved before any job is returned, so either every binding can be
+// applied or an error is returned.
+func (c *Config) Jobs(bindings []Binding, resolver *source.Resolver, clients rule.Clients) ([]Job, error) {
+	resolved := make(map[string][]rule.Rule)
+	jobs := make([]Job, 0, len(bindings))
+
+	for _, b := range bindings {
+		rules := make([]rule.Rule, 0)
+		for _, name := range b.RuleSets {
+			if _, ok := resolved[name]; !ok {
+				sources := c.RuleSets[name]
+				if sources == nil {
+					return nil, fmt.Errorf("binding %s: undefined rule set %s", b.Name, name)
+				}
+
+				generated, err := resolver.Resolve(sources)
+				if err != nil {
+					return nil, fmt.Errorf("rule set %s: %v", name, err)
+				}
+				resolved[name] = generated
+			}
+			rules = append(rules, resolved[name]...)
+		}
+
+		targets := b.Targets
+		if len(b.SecurityGroupTags) > 0 {
+			ids, err := SecurityGroupsByTags(b.SecurityGroupTags, clients.EC2)
+			if err != nil {
+				return nil, fmt.Errorf("binding %s: %v", b.Name, err)
+			}
+			if len(ids) == 0 {
+				log.Printf("WARNING: binding %s: no security groups match tags %v", b.Name, b.SecurityGroupTags)
+			}
+			targets.SecurityGroups = mergeIDs(targets.SecurityGroups, ids)
+		}
+
+		jobs = append(jobs, Job{Binding: b.Name, Rules: rules, Targets: targets.Build(clients), Options: resolver.Options(b.Options)})
+	}
+
+	return jobs, nil
+}
+
+// SecurityGroupsByTags returns the IDs of the security groups which have every
+// tag. An empty value matches any value of the tag.
+func SecurityGroupsByTags(tags map[string]string, ec2Client ec2iface.EC2API) ([]string, error) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	filters := make([]*ec2.Filter, 0, len(tags))
+	for _, key := range keys {
+		if tags[key] == "" {
+			filters = append(filters, &ec2.Filter{Name: aws.String("tag-key"), Values: aws.StringSlice([]string{key})})
+			continue
+		}
+		filters = append(filters, &ec2.Filter{Name: aws.String("tag:" + key), Values: aws.StringSlice([]string{tags[key]})})
+	}
+
+	ids := make([]string, 0)
+	err := ec2Client.DescribeSecurityGroupsPages(&ec2.DescribeSecurityGroupsInput{Filters: filters},
+		func(page *ec2.DescribeSecurityGroupsOutput, _ bool) bool {
+			for _, sg := range page.SecurityGroups {
+				ids = append(ids, aws.StringValue(sg.GroupId))
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// mergeIDs appends the IDs which are not already listed.
+func mergeIDs(ids, more []string) []string {
+	seen := make(map[string]bool)
+	merged := make([]string, 0, len(ids)+len(more))
+
+	for _, id := range append(append([]string{}, ids...), more...) {
+		if !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,404 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/source"
+	"github.com/stretchr/testify/assert"
+)
+
+const testYAML = `
+# Rule sets shared by every binding.
+ruleSets:
+  saas:
+    - type: dns
+      name: api.example.com
+  office:
+    - type: static
+      name: office
+      cidrs: [198.51.100.0/24]
+      ports: [22]
+      direction: ingress
+
+bindings:
+  - name: payments
+    ruleSets: [saas, office]
+    securityGroups: [sg-0123456789abcdef0]
+    securityGroupTags:
+      team: payments
+    owner: team-payments
+    maxShrinkPercent: 50
+`
+
+const testJSON = `{
+  "ruleSets": {
+    "saas": [{"type": "dns", "name": "api.example.com"}],
+    "office": [{"type": "static", "name": "office", "cidrs": ["198.51.100.0/24"], "ports": [22], "direction": "ingress"}]
+  },
+  "bindings": [{
+    "name": "payments",
+    "ruleSets": ["saas", "office"],
+    "securityGroups": ["sg-0123456789abcdef0"],
+    "securityGroupTags": {"team": "payments"},
+    "owner": "team-payments",
+    "maxShrinkPercent": 50
+  }]
+}`
+
+func TestParse(t *testing.T) {
+	fromYAML, err := Parse([]byte(testYAML), "config.yaml")
+	assert.NoError(t, err)
+
+	fromJSON, err := Parse([]byte(testJSON), "config")
+	assert.NoError(t, err)
+
+	assert.Equal(t, fromJSON, fromYAML)
+	assert.Equal(t, "team-payments", fromYAML.Bindings[0].Owner)
+	assert.Equal(t, []string{"sg-0123456789abcdef0"}, fromYAML.Bindings[0].SecurityGroups)
+	assert.Equal(t, []int{22}, fromYAML.RuleSets["office"][0].Ports)
+
+	_, err = Parse([]byte(testYAML), "config.json")
+	assert.Error(t, err)
+
+	_, err = Parse([]byte("bindings: []\nunknown: 1\n"), "config.yaml")
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	saas := map[string][]source.Source{"saas": {{Type: source.TypeDNS, Name: "api.example.com"}}}
+	binding := func(name string) Binding {
+		return Binding{Name: name, RuleSets: []string{"saas"}, Targets: rule.Targets{SecurityGroups: []string{"sg-" + name}}}
+	}
+	owned := func(b Binding, owner string) Binding {
+		b.Owner = owner
+		return b
+	}
+	shared := func(name string) Binding {
+		b := binding(name)
+		b.SecurityGroups = []string{"sg-shared"}
+		return b
+	}
+	tagged := func(name string, tags map[string]string) Binding {
+		return Binding{Name: name, RuleSets: []string{"saas"}, SecurityGroupTags: tags}
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+
+		expectErr bool
+	}{
+		{
+			name:   "Valid",
+			config: Config{RuleSets: saas, Bindings: []Binding{binding("a"), binding("b")}},
+		},
+		{
+			name: "TagsOnly",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				{Name: "a", RuleSets: []string{"saas"}, SecurityGroupTags: map[string]string{"team": ""}},
+			}},
+		},
+		{
+			name:      "SharedTarget",
+			config:    Config{RuleSets: saas, Bindings: []Binding{shared("a"), shared("b")}},
+			expectErr: true,
+		},
+		{
+			name:      "SharedTargetSameOwner",
+			config:    Config{RuleSets: saas, Bindings: []Binding{owned(shared("a"), "team-a"), owned(shared("b"), "team-a")}},
+			expectErr: true,
+		},
+		{
+			name:   "SharedTargetOwners",
+			config: Config{RuleSets: saas, Bindings: []Binding{owned(shared("a"), "team-a"), owned(shared("b"), "team-b")}},
+		},
+		{
+			name: "OverlappingTags",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				tagged("a", map[string]string{"team": "payments"}),
+				tagged("b", map[string]string{"env": "prod"}),
+			}},
+			expectErr: true,
+		},
+		{
+			name: "DisjointTags",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				tagged("a", map[string]string{"team": "payments"}),
+				tagged("b", map[string]string{"team": "search"}),
+			}},
+		},
+		{
+			name: "TagsAndSecurityGroup",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				tagged("a", map[string]string{"team": "payments"}),
+				binding("b"),
+			}},
+			expectErr: true,
+		},
+		{
+			name: "DisjointTagsAndSecurityGroup",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				tagged("a", map[string]string{"env": "prod"}),
+				{
+					Name:              "b",
+					RuleSets:          []string{"saas"},
+					SecurityGroupTags: map[string]string{"env": "dev"},
+					Targets:           rule.Targets{SecurityGroups: []string{"sg-1"}},
+				},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "SecurityGroupAndDisjointTags",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				{
+					Name:              "a",
+					RuleSets:          []string{"saas"},
+					SecurityGroupTags: map[string]string{"env": "dev"},
+					Targets:           rule.Targets{SecurityGroups: []string{"sg-1"}},
+				},
+				tagged("b", map[string]string{"env": "prod"}),
+			}},
+			expectErr: true,
+		},
+		{
+			name: "TagsOwners",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				owned(tagged("a", map[string]string{"team": "payments"}), "team-payments"),
+				binding("b"),
+			}},
+		},
+		{
+			name:      "NoBindings",
+			config:    Config{RuleSets: saas},
+			expectErr: true,
+		},
+		{
+			name:      "NoName",
+			config:    Config{RuleSets: saas, Bindings: []Binding{binding("")}},
+			expectErr: true,
+		},
+		{
+			name:      "DuplicateName",
+			config:    Config{RuleSets: saas, Bindings: []Binding{binding("a"), binding("a")}},
+			expectErr: true,
+		},
+		{
+			name:      "UndefinedRuleSet",
+			config:    Config{Bindings: []Binding{binding("a")}},
+			expectErr: true,
+		},
+		{
+			name: "EmptyRuleSet",
+			config: Config{
+				RuleSets: map[string][]source.Source{"saas": {}},
+				Bindings: []Binding{binding("a")},
+			},
+			expectErr: true,
+		},
+		{
+			name: "InvalidSource",
+			config: Config{
+				RuleSets: map[string][]source.Source{"saas": {{Type: source.TypeStatic, Name: "office"}}},
+				Bindings: []Binding{binding("a")},
+			},
+			expectErr: true,
+		},
+		{
+			name: "NoTargets",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				{Name: "a", RuleSets: []string{"saas"}},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "InvalidOwner",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				{Name: "a", RuleSets: []string{"saas"}, Targets: rule.Targets{SecurityGroups: []string{"sg-1"}}, Options: rule.Options{Owner: "team payments"}},
+			}},
+			expectErr: true,
+		},
+		{
+			name: "DomainListWithoutDNS",
+			config: Config{
+				RuleSets: map[string][]source.Source{"office": {{Type: source.TypeStatic, Name: "office", CIDRs: []string{"198.51.100.0/24"}}}},
+				Bindings: []Binding{{Name: "a", RuleSets: []string{"office"}, Targets: rule.Targets{DomainLists: []string{"rslvr-fdl-123"}}}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "InvalidNetworkACL",
+			config: Config{RuleSets: saas, Bindings: []Binding{
+				{Name: "a", RuleSets: []string{"saas"}, Targets: rule.Targets{NetworkACLs: []rule.NetworkACL{{ID: "acl-1", FirstRule: 200, LastRule: 100}}}},
+			}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Validate()
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(testYAML), 0600))
+
+	loader := &Loader{
+		S3:  &fakeS3Client{objects: map[string]string{"configs/dsg.yaml": testYAML}},
+		SSM: &fakeSSMClient{parameters: map[string]string{"/dsg/config": testJSON, "dsg": testJSON}},
+	}
+
+	for _, location := range []string{file, "file://" + file, "s3://configs/dsg.yaml", "ssm:///dsg/config", "ssm://dsg"} {
+		t.Run(location, func(t *testing.T) {
+			config, err := loader.Load(location)
+			assert.NoError(t, err)
+			if assert.NotNil(t, config) {
+				assert.Equal(t, "payments", config.Bindings[0].Name)
+			}
+		})
+	}
+
+	for _, location := range []string{filepath.Join(dir, "missing.yaml"), "s3://configs/missing.yaml", "ssm://missing", "https://example.com/config.yaml"} {
+		t.Run(location, func(t *testing.T) {
+			_, err := loader.Load(location)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJobs(t *testing.T) {
+	config, err := Parse([]byte(testYAML), "config.yaml")
+	assert.NoError(t, err)
+
+	resolver := &source.Resolver{LookupHost: func(string) ([]string, error) {
+		return []string{"203.0.113.10"}, nil
+	}}
+	client := &fakeEC2Client{groups: map[string]map[string]string{
+		"sg-0123456789abcdef0": {"team": "payments"},
+		"sg-2":                 {"team": "payments"},
+		"sg-3":                 {"team": "search"},
+	}}
+
+	jobs, err := config.Jobs(config.Bindings, resolver, rule.Clients{EC2: client})
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	job := jobs[0]
+	assert.Equal(t, "payments", job.Binding)
+	assert.Equal(t, "team-payments", job.Options.Owner)
+	assert.Len(t, job.Rules, 2)
+
+	targets := make([]string, len(job.Targets))
+	for i, target := range job.Targets {
+		targets[i] = target.String()
+	}
+	assert.Equal(t, []string{"sg-0123456789abcdef0", "sg-2"}, targets)
+
+	config.Bindings = append(config.Bindings, Binding{
+		Name:     "search",
+		RuleSets: []string{"saas"},
+		Targets:  rule.Targets{SecurityGroups: []string{"sg-2"}},
+		Options:  rule.Options{Owner: "team-search"},
+	})
+	jobs, err = config.Jobs(config.Bindings, resolver, rule.Clients{EC2: client})
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	selected, err := config.Select([]string{"search"})
+	assert.NoError(t, err)
+	assert.Len(t, selected, 1)
+	assert.Equal(t, "search", selected[0].Name)
+
+	_, err = config.Select([]string{"missing"})
+	assert.Error(t, err)
+}
+
+// fakeS3Client serves objects from memory.
+type fakeS3Client struct {
+	s3iface.S3API
+
+	objects map[string]string
+}
+
+func (c *fakeS3Client) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	object, ok := c.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)]
+	if !ok {
+		return nil, errors.New("NoSuchKey")
+	}
+
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader([]byte(object)))}, nil
+}
+
+// fakeSSMClient serves parameters from memory.
+type fakeSSMClient struct {
+	ssmiface.SSMAPI
+
+	parameters map[string]string
+}
+
+func (c *fakeSSMClient) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+	value, ok := c.parameters[aws.StringValue(input.Name)]
+	if !ok {
+		return nil, errors.New("ParameterNotFound")
+	}
+
+	return &ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String(value)}}, nil
+}
+
+// fakeEC2Client filters security groups by tag.
+type fakeEC2Client struct {
+	ec2iface.EC2API
+
+	groups map[string]map[string]string
+}
+
+func (c *fakeEC2Client) DescribeSecurityGroupsPages(input *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
+	page := &ec2.DescribeSecurityGroupsOutput{}
+
+	for id, tags := range c.groups {
+		matches := true
+		for _, filter := range input.Filters {
+			name := aws.StringValue(filter.Name)
+			value := aws.StringValue(filter.Values[0])
+
+			if name == "tag-key" {
+				_, ok := tags[value]
+				matches = matches && ok
+				continue
+			}
+			matches = matches && tags[name[len("tag:"):]] == value
+		}
+
+		if matches {
+			page.SecurityGroups = append(page.SecurityGroups, &ec2.SecurityGroup{GroupId: aws.String(id)})
+		}
+	}
+
+	fn(page, true)
+	return nil
+}
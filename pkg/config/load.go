@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+)
+
+// Loader reads configurations from files, S3 objects and SSM parameters.
+type Loader struct {
+	// Session creates the S3 and SSM clients when they are not set.
+	// Defaults to a new session.
+	Session *session.Session
+
+	// S3 and SSM are the clients used to read s3:// and ssm:// locations.
+	S3  s3iface.S3API
+	SSM ssmiface.SSMAPI
+}
+
+// Load reads and parses the configuration at a location, which is one of:
+//
+//	path/to/config.yaml or file://path/to/config.yaml
+//	s3://bucket/key, or s3://bucket/key?endpoint=URL for S3 compatible stores
+//	ssm://name, or ssm:///path/to/name for hierarchical parameters
+func (l *Loader) Load(location string) (*Config, error) {
+	data, err := l.read(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %v", location, err)
+	}
+
+	return Parse(data, location)
+}
+
+// read returns the raw configuration at a location.
+func (l *Loader) read(location string) ([]byte, error) {
+	if !strings.Contains(location, "://") {
+		return ioutil.ReadFile(location)
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "file":
+		return ioutil.ReadFile(filepath.FromSlash(u.Host + u.Path))
+	case "s3":
+		return l.readS3(u)
+	case "ssm":
+		return l.readSSM(u)
+	default:
+		return nil, fmt.Errorf("unsupported config location: %s", location)
+	}
+}
+
+// readS3 downloads an s3://bucket/key URL.
+func (l *Loader) readS3(u *url.URL) ([]byte, error) {
+	client := l.S3
+	if endpoint := u.Query().Get("endpoint"); endpoint != "" {
+		// S3 compatible stores are usually addressed by path rather than
+		// by bucket subdomain.
+		client = s3.New(l.session(), aws.NewConfig().WithEndpoint(endpoint).WithS3ForcePathStyle(true))
+	}
+	if client == nil {
+		l.S3 = s3.New(l.session())
+		client = l.S3
+	}
+
+	res, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(u.Host),
+		Key:    aws.String(strings.TrimPrefix(u.Path, "/")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return ioutil.ReadAll(res.Body)
+}
+
+// readSSM reads an ssm://name URL. SecureString parameters are decrypted.
+func (l *Loader) readSSM(u *url.URL) ([]byte, error) {
+	if l.SSM == nil {
+		l.SSM = ssm.New(l.session())
+	}
+
+	name := u.Host + u.Path
+	if name == "" {
+		return nil, fmt.Errorf("no parameter name in %s", u)
+	}
+
+	res, err := l.SSM.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(aws.StringValue(res.Parameter.Value)), nil
+}
+
+// session returns the session used to create clients.
+func (l *Loader) session() *session.Session {
+	if l.Session == nil {
+		l.Session = session.New()
+	}
+	return l.Session
+}
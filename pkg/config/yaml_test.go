@@ -0,0 +1,87 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+
+		expectTags map[string]string
+		err        bool
+	}{
+		{
+			name: "YAML",
+			file: "config.yaml",
+			data: testYAML,
+		},
+		{
+			name: "YML",
+			file: "config.yml",
+			data: testYAML,
+		},
+		{
+			name: "YAMLWithoutExtension",
+			file: "ssm:///dsg/config",
+			data: testYAML,
+		},
+		{
+			name: "JSONWithoutExtension",
+			file: "ssm:///dsg/config",
+			data: testJSON,
+		},
+		{
+			name: "JSONAsYAML",
+			file: "config.yaml",
+			data: testJSON,
+		},
+		{
+			name: "YAMLAsJSON",
+			file: "config.json",
+			data: testYAML,
+			err:  true,
+		},
+		{
+			name:       "ApostropheAndComment",
+			file:       "config.yaml",
+			data:       strings.Replace(testYAML, "team: payments\n", "team: payments\n      owner: ops's group # comment\n", 1),
+			expectTags: map[string]string{"team": "payments", "owner": "ops's group"},
+		},
+		{
+			name:       "QuotedNumber",
+			file:       "config.yaml",
+			data:       strings.Replace(testYAML, "team: payments\n", "team: payments\n      account: \"012345678901\"\n", 1),
+			expectTags: map[string]string{"team": "payments", "account": "012345678901"},
+		},
+		{
+			name: "UnquotedNumber",
+			file: "config.yaml",
+			data: strings.Replace(testYAML, "team: payments\n", "team: payments\n      account: 012345678901\n", 1),
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := Parse([]byte(test.data), test.file)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			expectTags := test.expectTags
+			if expectTags == nil {
+				expectTags = map[string]string{"team": "payments"}
+			}
+			assert.Equal(t, expectTags, config.Bindings[0].SecurityGroupTags)
+		})
+	}
+}
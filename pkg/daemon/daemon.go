@@ -0,0 +1,336 @@
+// Package daemon runs jobs on intervals in a long-running process, for
+// environments without lambda such as on-premises runners and ECS sidecars.
+// Each job is scheduled with jitter, runs of the same job never overlap, and
+// the status of every job is served over HTTP.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults.
+const (
+	// DefaultJitter is the default fraction of a job's interval by which
+	// each run is randomly delayed.
+	DefaultJitter = 0.1
+
+	// DefaultListen is the default address of the HTTP endpoint, which is
+	// only reachable locally.
+	DefaultListen = "127.0.0.1:9720"
+
+	// MinInterval is the shortest interval of a job.
+	MinInterval = 10 * time.Second
+)
+
+// random returns a random number in [0, 1), and after waits for a duration.
+// They are replaced in tests.
+var (
+	random = rand.Float64
+	after  = time.After
+)
+
+// Duration is a duration which is encoded in JSON as a string, such as "5m".
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON encodes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid duration %s: expected a string such as \"5m\"", data)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+
+	return nil
+}
+
+// Job is an event which is run on an interval.
+type Job struct {
+	// Name identifies the job in logs and status.
+	Name string `json:"name"`
+
+	// Interval is the time between the end of one run and the start of the
+	// next, before jitter.
+	Interval Duration `json:"interval"`
+
+	// Event is the event to run, such as a dns-firewall or aws-api-egress
+	// event.
+	Event json.RawMessage `json:"event"`
+}
+
+// Config is the JSON configuration of a daemon.
+type Config struct {
+	// Jobs are the jobs to run.
+	Jobs []Job `json:"jobs"`
+
+	// Jitter is the largest fraction of a job's interval by which each run
+	// is randomly delayed, so jobs with the same interval do not run at
+	// the same time. Defaults to DefaultJitter, and a negative value
+	// disables jitter.
+	Jitter *float64 `json:"jitter,omitempty"`
+
+	// Listen is the address of the HTTP endpoint. Defaults to
+	// DefaultListen.
+	Listen string `json:"listen,omitempty"`
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *Config) Validate() error {
+	if len(c.Jobs) == 0 {
+		return errors.New("no jobs are configured")
+	}
+
+	names := make(map[string]bool)
+	for _, job := range c.Jobs {
+		if job.Name == "" {
+			return errors.New("a job has no name")
+		}
+		if names[job.Name] {
+			return fmt.Errorf("duplicate job %s", job.Name)
+		}
+		names[job.Name] = true
+
+		if job.Interval.Duration < MinInterval {
+			return fmt.Errorf("job %s: interval %s is shorter than %s", job.Name, job.Interval, MinInterval)
+		}
+		if len(job.Event) == 0 {
+			return fmt.Errorf("job %s has no event", job.Name)
+		}
+	}
+
+	if c.Jitter != nil && *c.Jitter >= 1 {
+		return fmt.Errorf("jitter %g must be less than 1", *c.Jitter)
+	}
+
+	return nil
+}
+
+// jitter returns the jitter fraction.
+func (c *Config) jitter() float64 {
+	if c.Jitter == nil {
+		return DefaultJitter
+	}
+	if *c.Jitter < 0 {
+		return 0
+	}
+	return *c.Jitter
+}
+
+// ListenAddress returns the address of the HTTP endpoint.
+func (c *Config) ListenAddress() string {
+	if c.Listen == "" {
+		return DefaultListen
+	}
+	return c.Listen
+}
+
+// RunFunc runs one job. The context is cancelled when the daemon stops.
+type RunFunc func(ctx context.Context, job Job) error
+
+// Status is the status of a job.
+type Status struct {
+	Name     string   `json:"name"`
+	Interval Duration `json:"interval"`
+
+	// Running is true while the job runs.
+	Running bool `json:"running"`
+
+	// Runs and Failures count the finished runs, and the ones which
+	// failed.
+	Runs     int `json:"runs"`
+	Failures int `json:"failures"`
+
+	// LastStart, LastDuration and LastError describe the last finished
+	// run. LastSuccess is the end of the last successful run.
+	LastStart    *time.Time `json:"lastStart,omitempty"`
+	LastDuration *Duration  `json:"lastDuration,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+	LastSuccess  *time.Time `json:"lastSuccess,omitempty"`
+
+	// NextRun is the scheduled start of the next run.
+	NextRun *time.Time `json:"nextRun,omitempty"`
+}
+
+// Daemon runs jobs on their intervals.
+type Daemon struct {
+	config Config
+	run    RunFunc
+
+	mu     sync.Mutex
+	status map[string]*Status
+}
+
+// New creates a daemon which runs the configured jobs with run.
+func New(config Config, run RunFunc) (*Daemon, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	d := &Daemon{
+		config: config,
+		run:    run,
+		status: make(map[string]*Status),
+	}
+	for _, job := range config.Jobs {
+		d.status[job.Name] = &Status{Name: job.Name, Interval: job.Interval}
+	}
+
+	return d, nil
+}
+
+// Run runs every job until the context is cancelled, and returns once the
+// runs in progress finish. Runs are never interrupted part way through.
+func (d *Daemon) Run(ctx context.Context) {
+	wg := &sync.WaitGroup{}
+
+	for _, job := range d.config.Jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			d.loop(ctx, job)
+		}(job)
+	}
+
+	wg.Wait()
+}
+
+// loop runs a job until the context is cancelled. The first run is delayed by
+// up to the jitter, so jobs do not all start at once, and each run is
+// scheduled after the previous one finishes, so runs never overlap.
+func (d *Daemon) loop(ctx context.Context, job Job) {
+	jitter := d.config.jitter()
+	delay := time.Duration(random() * jitter * float64(job.Interval.Duration))
+
+	for {
+		next := time.Now().Add(delay)
+		d.update(job.Name, func(s *Status) {
+			s.NextRun = &next
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-after(delay):
+		}
+
+		d.runOnce(ctx, job)
+
+		delay = job.Interval.Duration + time.Duration(random()*jitter*float64(job.Interval.Duration))
+	}
+}
+
+// runOnce runs a job and records its status.
+func (d *Daemon) runOnce(ctx context.Context, job Job) {
+	start := time.Now()
+	d.update(job.Name, func(s *Status) {
+		s.Running = true
+		s.NextRun = nil
+	})
+
+	log.Printf("Running job %s", job.Name)
+	err := d.run(ctx, job)
+
+	end := time.Now()
+	duration := Duration{end.Sub(start)}
+
+	d.update(job.Name, func(s *Status) {
+		s.Running = false
+		s.Runs++
+		s.LastStart = &start
+		s.LastDuration = &duration
+		s.LastError = ""
+
+		if err != nil {
+			s.Failures++
+			s.LastError = err.Error()
+		} else {
+			s.LastSuccess = &end
+		}
+	})
+
+	if err != nil {
+		log.Printf("Job %s failed after %s: %v", job.Name, duration, err)
+		return
+	}
+	log.Printf("Job %s finished in %s", job.Name, duration)
+}
+
+// update modifies the status of a job.
+func (d *Daemon) update(name string, f func(s *Status)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	f(d.status[name])
+}
+
+// Status returns the status of every job, sorted by name.
+func (d *Daemon) Status() []Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make([]Status, 0, len(d.status))
+	for _, s := range d.status {
+		result = append(result, *s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// Handler serves the status of the jobs. /healthz responds with 200 unless
+// the last run of a job failed, and /status responds with the status of every
+// job as JSON.
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		failed := make([]string, 0)
+		for _, s := range d.Status() {
+			if s.LastError != "" {
+				failed = append(failed, s.Name)
+			}
+		}
+
+		if len(failed) > 0 {
+			http.Error(w, "failed jobs: "+strings.Join(failed, ", "), http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(d.Status()); err != nil {
+			log.Printf("Failed to write status: %v", err)
+		}
+	})
+
+	return mux
+}
@@ -0,0 +1,181 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	jitter := func(f float64) *float64 {
+		return &f
+	}
+	job := func(name string, interval time.Duration) Job {
+		return Job{Name: name, Interval: Duration{interval}, Event: json.RawMessage(`{}`)}
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+
+		expectErr bool
+	}{
+		{
+			name:   "Valid",
+			config: Config{Jobs: []Job{job("dns", time.Minute), job("egress", time.Hour)}},
+		},
+		{
+			name:      "NoJobs",
+			config:    Config{},
+			expectErr: true,
+		},
+		{
+			name:      "NoName",
+			config:    Config{Jobs: []Job{job("", time.Minute)}},
+			expectErr: true,
+		},
+		{
+			name:      "DuplicateName",
+			config:    Config{Jobs: []Job{job("dns", time.Minute), job("dns", time.Hour)}},
+			expectErr: true,
+		},
+		{
+			name:      "ShortInterval",
+			config:    Config{Jobs: []Job{job("dns", time.Second)}},
+			expectErr: true,
+		},
+		{
+			name:      "NoEvent",
+			config:    Config{Jobs: []Job{{Name: "dns", Interval: Duration{time.Minute}}}},
+			expectErr: true,
+		},
+		{
+			name:      "InvalidJitter",
+			config:    Config{Jobs: []Job{job("dns", time.Minute)}, Jitter: jitter(1)},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Validate()
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	job := Job{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "dns", "interval": "5m"}`), &job))
+	assert.Equal(t, 5*time.Minute, job.Interval.Duration)
+
+	data, err := json.Marshal(job.Interval)
+	assert.NoError(t, err)
+	assert.Equal(t, `"5m0s"`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"interval": 300}`), &job))
+	assert.Error(t, json.Unmarshal([]byte(`{"interval": "5 minutes"}`), &job))
+}
+
+func TestDaemonRun(t *testing.T) {
+	random = func() float64 { return 0.5 }
+	defer func() {
+		random = rand.Float64
+		after = time.After
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := 0
+	delays := make([]time.Duration, 0)
+
+	// Every wait finishes immediately until the third run, and then never.
+	after = func(d time.Duration) <-chan time.Time {
+		delays = append(delays, d)
+		ch := make(chan time.Time, 1)
+		if runs < 3 {
+			ch <- time.Time{}
+		}
+		return ch
+	}
+
+	d, err := New(Config{
+		Jobs: []Job{{Name: "dns", Interval: Duration{time.Minute}, Event: json.RawMessage(`{}`)}},
+	}, func(_ context.Context, job Job) error {
+		runs++
+		if runs == 2 {
+			return errors.New("throttled")
+		}
+		if runs == 3 {
+			cancel()
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	d.Run(ctx)
+
+	assert.Equal(t, 3, runs)
+	assert.Equal(t, []time.Duration{3 * time.Second, 63 * time.Second, 63 * time.Second, 63 * time.Second}, delays)
+
+	status := d.Status()
+	assert.Len(t, status, 1)
+	assert.Equal(t, 3, status[0].Runs)
+	assert.Equal(t, 1, status[0].Failures)
+	assert.Empty(t, status[0].LastError)
+	assert.False(t, status[0].Running)
+	assert.NotNil(t, status[0].LastSuccess)
+}
+
+func TestHandler(t *testing.T) {
+	d, err := New(Config{
+		Jobs: []Job{
+			{Name: "dns", Interval: Duration{time.Minute}, Event: json.RawMessage(`{}`)},
+			{Name: "egress", Interval: Duration{time.Hour}, Event: json.RawMessage(`{}`)},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		d.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/healthz")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	d.update("egress", func(s *Status) {
+		s.Runs++
+		s.Failures++
+		s.LastError = "access denied"
+	})
+
+	w = get("/healthz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "egress")
+
+	w = get("/status")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	status := make([]Status, 0)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Len(t, status, 2)
+	assert.Equal(t, "dns", status[0].Name)
+	assert.Equal(t, time.Minute, status[0].Interval.Duration)
+	assert.Equal(t, "access denied", status[1].LastError)
+}
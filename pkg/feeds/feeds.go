@@ -0,0 +1,258 @@
+// Package feeds reads IP range feeds published by cloud and SaaS providers
+// other than AWS. AWS IP ranges are read by package awsips.
+package feeds
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/selector"
+)
+
+// Entry is a group of CIDRs in a feed. Each entry has one or more names, which
+// selectors are matched against. The format of the names depends on the
+// provider.
+type Entry struct {
+	Names []string
+	CIDRs []string
+}
+
+// Parser parses a feed into entries.
+type Parser func(data []byte) ([]Entry, error)
+
+// Provider is a publisher of an IP range feed.
+type Provider struct {
+	// Name identifies the provider.
+	Name string
+
+	// URLs are the default locations of the feed. Providers which publish
+	// more than one file, such as separate IPv4 and IPv6 lists, have more
+	// than one URL.
+	URLs []string
+
+	// Parse parses the feed.
+	Parse Parser
+}
+
+// Providers are the supported providers, keyed by name.
+var Providers = map[string]Provider{
+	"google": {
+		Name:  "google",
+		URLs:  []string{"https://www.gstatic.com/ipranges/goog.json"},
+		Parse: ParseGoogle,
+	},
+	"google-cloud": {
+		Name:  "google-cloud",
+		URLs:  []string{"https://www.gstatic.com/ipranges/cloud.json"},
+		Parse: ParseGoogle,
+	},
+	"github": {
+		Name:  "github",
+		URLs:  []string{"https://api.github.com/meta"},
+		Parse: ParseGitHub,
+	},
+	"cloudflare": {
+		Name:  "cloudflare",
+		URLs:  []string{"https://www.cloudflare.com/ips-v4", "https://www.cloudflare.com/ips-v6"},
+		Parse: ParseCloudflare,
+	},
+	"atlassian": {
+		Name:  "atlassian",
+		URLs:  []string{"https://ip-ranges.atlassian.com/"},
+		Parse: ParseAtlassian,
+	},
+	"azure": {
+		// Azure service tags are published at a URL which changes weekly,
+		// so there is no default.
+		Name:  "azure",
+		Parse: ParseAzure,
+	},
+}
+
+// Getter downloads and parses a provider's feed.
+type Getter struct {
+	provider Provider
+
+	// urls are the locations to download the feed from.
+	urls []string
+
+	httpClient *http.Client
+	entries    []Entry
+	getErr     error
+	getOnce    sync.Once
+}
+
+// NewGetter creates a Getter for a provider. If no URLs are given, the
+// provider's default URLs are used. URLs may be http(s):// or file:// URLs.
+func NewGetter(provider string, urls ...string) (*Getter, error) {
+	p, ok := Providers[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q, expected one of: %s", provider, strings.Join(providerNames(), ", "))
+	}
+
+	if len(urls) == 0 {
+		urls = p.URLs
+	}
+
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("provider %s has no default URL", provider)
+	}
+
+	return &Getter{
+		provider: p,
+		urls:     urls,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// Entries gets the entries in the feed.
+func (g *Getter) Entries() ([]Entry, error) {
+	g.getOnce.Do(func() {
+		for _, uri := range g.urls {
+			var data []byte
+			if data, g.getErr = g.fetch(uri); g.getErr != nil {
+				return
+			}
+
+			var entries []Entry
+			if entries, g.getErr = g.provider.Parse(data); g.getErr != nil {
+				g.getErr = fmt.Errorf("failed to parse %s: %v", uri, g.getErr)
+				return
+			}
+
+			g.entries = append(g.entries, entries...)
+		}
+	})
+
+	return g.entries, g.getErr
+}
+
+// Select gets the CIDRs of entries with a name matching any of the selectors.
+// Duplicate CIDRs are removed.
+func (g *Getter) Select(patterns []string) ([]string, error) {
+	selectors, err := selector.NewList(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := g.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	cidrs := make([]string, 0)
+	for _, entry := range entries {
+		if !matchEntry(selectors, entry) {
+			continue
+		}
+
+		for _, cidr := range entry.CIDRs {
+			if !seen[cidr] {
+				seen[cidr] = true
+				cidrs = append(cidrs, cidr)
+			}
+		}
+	}
+
+	return cidrs, nil
+}
+
+// Names returns the sorted unique names of the entries in the feed.
+func (g *Getter) Names() ([]string, error) {
+	entries, err := g.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, entry := range entries {
+		for _, name := range entry.Names {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// fetch gets the raw feed from a URL.
+func (g *Getter) fetch(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(u.Scheme) == "file" {
+		return ioutil.ReadFile(filepath.FromSlash(u.Host + u.Path))
+	}
+
+	log.Printf("GET %s", uri)
+
+	res, err := g.httpClient.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Got status: %d", res.StatusCode)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+// IPv4 returns the IPv4 CIDRs in a list.
+func IPv4(cidrs []string) []string {
+	result := make([]string, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() != nil {
+			result = append(result, cidr)
+		}
+	}
+	return result
+}
+
+// matchEntry returns a boolean for whether any of an entry's names match any
+// of the selectors.
+func matchEntry(selectors []selector.Selector, entry Entry) bool {
+	for _, name := range entry.Names {
+		if selector.MatchAny(selectors, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// providerNames returns the sorted names of the supported providers.
+func providerNames() []string {
+	names := make([]string, 0, len(Providers))
+	for name := range Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// family returns "ipv6" for IPv6 CIDRs, and "ipv4" otherwise.
+func family(cidr string) string {
+	if strings.Contains(cidr, ":") {
+		return "ipv6"
+	}
+	return "ipv4"
+}
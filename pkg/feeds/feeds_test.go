@@ -0,0 +1,180 @@
+package feeds
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		urls      []string
+		selectors []string
+
+		expect []string
+		err    bool
+	}{
+		{
+			name:      "GoogleAll",
+			provider:  "google",
+			urls:      []string{"file://testdata/goog.json"},
+			selectors: []string{"google"},
+			expect:    []string{"8.8.4.0/24", "8.8.8.0/24", "34.64.0.0/10", "2001:4860::/32"},
+		},
+		{
+			name:      "GoogleCloudScope",
+			provider:  "google-cloud",
+			urls:      []string{"file://testdata/cloud.json"},
+			selectors: []string{"us-*"},
+			expect:    []string{"34.68.0.0/14", "35.184.0.0/16", "34.73.0.0/16", "2600:1900:4000::/44"},
+		},
+		{
+			name:      "GitHubHooksAndAPI",
+			provider:  "github",
+			urls:      []string{"file://testdata/github-meta.json"},
+			selectors: []string{"hooks", "api"},
+			expect: []string{
+				"192.30.252.0/22",
+				"185.199.108.0/22",
+				"140.82.112.0/20",
+				"143.55.64.0/20",
+				"20.201.28.148/32",
+				"2a0a:a440::/29",
+				"2606:50c0::/32",
+			},
+		},
+		{
+			name:      "CloudflareIPv4",
+			provider:  "cloudflare",
+			urls:      []string{"file://testdata/cloudflare-ips-v4.txt", "file://testdata/cloudflare-ips-v6.txt"},
+			selectors: []string{"ipv4"},
+			expect: []string{
+				"173.245.48.0/20",
+				"103.21.244.0/22",
+				"103.22.200.0/22",
+				"103.31.4.0/22",
+				"141.101.64.0/18",
+			},
+		},
+		{
+			name:      "CloudflareAll",
+			provider:  "cloudflare",
+			urls:      []string{"file://testdata/cloudflare-ips-v4.txt", "file://testdata/cloudflare-ips-v6.txt"},
+			selectors: []string{"*"},
+			expect: []string{
+				"173.245.48.0/20",
+				"103.21.244.0/22",
+				"103.22.200.0/22",
+				"103.31.4.0/22",
+				"141.101.64.0/18",
+				"2400:cb00::/32",
+				"2606:4700::/32",
+				"2803:f800::/32",
+			},
+		},
+		{
+			name:      "AtlassianJiraEgress",
+			provider:  "atlassian",
+			urls:      []string{"file://testdata/atlassian.json"},
+			selectors: []string{"jira/*/egress"},
+			expect:    []string{"13.52.5.0/25", "18.184.99.128/25"},
+		},
+		{
+			name:      "AtlassianBitbucketIngress",
+			provider:  "atlassian",
+			urls:      []string{"file://testdata/atlassian.json"},
+			selectors: []string{"bitbucket/global/ingress"},
+			expect:    []string{"104.192.136.0/21", "2401:1d80:3000::/36"},
+		},
+		{
+			name:      "AzureStorage",
+			provider:  "azure",
+			urls:      []string{"file://testdata/azure-service-tags.json"},
+			selectors: []string{"Storage.*"},
+			expect:    []string{"13.88.144.240/28", "20.60.34.0/23", "20.38.98.0/24", "20.60.0.0/24"},
+		},
+		{
+			name:      "AzureRegexp",
+			provider:  "azure",
+			urls:      []string{"file://testdata/azure-service-tags.json"},
+			selectors: []string{"/^(AzureCloud|Storage)\\.(australiacentral|WestUS)$/"},
+			expect:    []string{"4.198.0.0/16", "20.36.32.0/19", "2603:1010:300::/47", "13.88.144.240/28", "20.60.34.0/23"},
+		},
+		{
+			name:      "NoMatches",
+			provider:  "github",
+			urls:      []string{"file://testdata/github-meta.json"},
+			selectors: []string{"copilot"},
+			expect:    []string{},
+		},
+		{
+			name:      "WrongFormat",
+			provider:  "azure",
+			urls:      []string{"file://testdata/github-meta.json"},
+			selectors: []string{"*"},
+			err:       true,
+		},
+		{
+			name:      "InvalidSelector",
+			provider:  "github",
+			urls:      []string{"file://testdata/github-meta.json"},
+			selectors: []string{"/(/"},
+			err:       true,
+		},
+		{
+			name:      "MissingFile",
+			provider:  "github",
+			urls:      []string{"file://testdata/missing.json"},
+			selectors: []string{"*"},
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getter, err := NewGetter(test.provider, test.urls...)
+			assert.NoError(t, err)
+
+			result, err := getter.Select(test.selectors)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func TestHTTPFeed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/github-meta.json")
+	}))
+	defer ts.Close()
+
+	getter, err := NewGetter("github", ts.URL)
+	assert.NoError(t, err)
+
+	names, err := getter.Names()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"actions", "api", "git", "hooks", "web"}, names)
+}
+
+func TestNewGetterError(t *testing.T) {
+	_, err := NewGetter("oracle")
+	assert.Error(t, err)
+
+	_, err = NewGetter("azure")
+	assert.Error(t, err)
+}
+
+func TestIPv4(t *testing.T) {
+	result := IPv4([]string{"8.8.8.0/24", "2001:4860::/32", "not a cidr", "34.64.0.0/10"})
+	assert.Equal(t, []string{"8.8.8.0/24", "34.64.0.0/10"}, result)
+}
@@ -0,0 +1,199 @@
+package feeds
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// ParseGoogle parses the Google goog.json and cloud.json feeds. Entries are
+// named by their scope, such as "us-central1". Entries without a scope, which
+// is every entry in goog.json, are named "google".
+func ParseGoogle(data []byte) ([]Entry, error) {
+	doc := struct {
+		Prefixes []struct {
+			IPv4Prefix string `json:"ipv4Prefix"`
+			IPv6Prefix string `json:"ipv6Prefix"`
+			Scope      string `json:"scope"`
+		} `json:"prefixes"`
+	}{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(doc.Prefixes))
+	for _, prefix := range doc.Prefixes {
+		name := prefix.Scope
+		if name == "" {
+			name = "google"
+		}
+
+		cidr := prefix.IPv4Prefix
+		if cidr == "" {
+			cidr = prefix.IPv6Prefix
+		}
+
+		entries = append(entries, Entry{
+			Names: []string{name},
+			CIDRs: []string{cidr},
+		})
+	}
+
+	return checkEntries(entries)
+}
+
+// ParseGitHub parses the GitHub meta API. Entries are named by their key, such
+// as "hooks", "api" or "actions". Keys which are not lists of CIDRs are
+// ignored.
+func ParseGitHub(data []byte) ([]Entry, error) {
+	doc := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]Entry, 0, len(doc))
+	for _, key := range keys {
+		var cidrs []string
+		if err := json.Unmarshal(doc[key], &cidrs); err != nil || len(cidrs) == 0 {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(cidrs[0]); err != nil {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Names: []string{key},
+			CIDRs: cidrs,
+		})
+	}
+
+	return checkEntries(entries)
+}
+
+// ParseCloudflare parses the Cloudflare ips-v4 and ips-v6 lists, which have
+// one CIDR per line. Entries are named by address family, "ipv4" or "ipv6".
+func ParseCloudflare(data []byte) ([]Entry, error) {
+	entries := make([]Entry, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		cidr := strings.TrimSpace(scanner.Text())
+		if cidr == "" {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Names: []string{family(cidr)},
+			CIDRs: []string{cidr},
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return checkEntries(entries)
+}
+
+// ParseAtlassian parses the Atlassian IP ranges feed. Entries are named
+// "product/region/direction", such as "jira/us-west-1/egress", with a name
+// for every combination of the entry's products, regions and directions.
+func ParseAtlassian(data []byte) ([]Entry, error) {
+	doc := struct {
+		Items []struct {
+			CIDR      string   `json:"cidr"`
+			Region    []string `json:"region"`
+			Product   []string `json:"product"`
+			Direction []string `json:"direction"`
+		} `json:"items"`
+	}{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		names := make([]string, 0)
+		for _, product := range orAll(item.Product) {
+			for _, region := range orAll(item.Region) {
+				for _, direction := range orAll(item.Direction) {
+					names = append(names, product+"/"+region+"/"+direction)
+				}
+			}
+		}
+
+		entries = append(entries, Entry{
+			Names: names,
+			CIDRs: []string{item.CIDR},
+		})
+	}
+
+	return checkEntries(entries)
+}
+
+// ParseAzure parses an Azure service tags file. Entries are named by service
+// tag, such as "Storage.WestUS" or "AzureCloud.australiacentral".
+func ParseAzure(data []byte) ([]Entry, error) {
+	doc := struct {
+		Values []struct {
+			Name       string `json:"name"`
+			Properties struct {
+				AddressPrefixes []string `json:"addressPrefixes"`
+			} `json:"properties"`
+		} `json:"values"`
+	}{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(doc.Values))
+	for _, value := range doc.Values {
+		entries = append(entries, Entry{
+			Names: []string{value.Name},
+			CIDRs: value.Properties.AddressPrefixes,
+		})
+	}
+
+	return checkEntries(entries)
+}
+
+// checkEntries returns an error if there are no entries, or if any CIDR is
+// invalid.
+func checkEntries(entries []Entry) ([]Entry, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("feed contains no entries")
+	}
+
+	for _, entry := range entries {
+		for _, cidr := range entry.CIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %v", cidr, err)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// orAll returns values, or "all" if there are none.
+func orAll(values []string) []string {
+	if len(values) == 0 {
+		return []string{"all"}
+	}
+	return values
+}
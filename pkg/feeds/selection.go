@@ -0,0 +1,99 @@
+package feeds
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// SourceType is the source type of rules generated from feeds.
+const SourceType = "feed"
+
+// Selection selects CIDRs from a provider's feed, and describes the rule to
+// create for them.
+type Selection struct {
+	// Provider is the name of the provider, such as "github".
+	Provider string `json:"provider"`
+
+	// URLs override the provider's default feed URLs.
+	URLs []string `json:"urls"`
+
+	// Selectors are matched against the names of the entries in the feed.
+	Selectors []string `json:"selectors"`
+
+	// Port is the port to allow traffic to.
+	Port int `json:"port"`
+
+	// Protocol is the network protocol.
+	Protocol string `json:"protocol"`
+
+	// Egress specifies whether the rule is ingress (default) or egress.
+	Egress bool `json:"egress"`
+}
+
+// Name returns the rule name for the selection, such as "github:hooks,api".
+func (s Selection) Name() string {
+	return s.Provider + ":" + strings.Join(s.Selectors, ",")
+}
+
+// Validate returns an error if the selection's rule configuration is invalid.
+func (s Selection) Validate() error {
+	if s.Provider == "" {
+		return fmt.Errorf("feed has no provider")
+	}
+
+	switch s.Protocol {
+	case rule.ProtocolTCP, rule.ProtocolUDP:
+	default:
+		return fmt.Errorf("feed %s: unsupported protocol %q", s.Name(), s.Protocol)
+	}
+
+	if s.Port < 1 || s.Port > 65535 {
+		return fmt.Errorf("feed %s: invalid port %d", s.Name(), s.Port)
+	}
+
+	return nil
+}
+
+// Rule gets the selected CIDRs and returns them as a rule. Security group
+// rules only support IPv4 CIDRs, so IPv6 CIDRs are skipped. It is an error if
+// no IPv4 CIDRs are selected, since applying an empty rule would remove every
+// CIDR of the feed.
+func (s Selection) Rule() (rule.Rule, error) {
+	if err := s.Validate(); err != nil {
+		return rule.Rule{}, err
+	}
+
+	getter, err := NewGetter(s.Provider, s.URLs...)
+	if err != nil {
+		return rule.Rule{}, err
+	}
+
+	cidrs, err := getter.Select(s.Selectors)
+	if err != nil {
+		return rule.Rule{}, err
+	}
+
+	if len(cidrs) == 0 {
+		return rule.Rule{}, fmt.Errorf("feed %s: selectors matched no CIDRs", s.Name())
+	}
+
+	ipv4 := IPv4(cidrs)
+	if skipped := len(cidrs) - len(ipv4); skipped > 0 {
+		log.Printf("Skipped %d IPv6 CIDRs for %s", skipped, s.Name())
+	}
+	if len(ipv4) == 0 {
+		return rule.Rule{}, fmt.Errorf("feed %s: selectors matched no IPv4 CIDRs", s.Name())
+	}
+
+	return rule.Rule{
+		Name:       s.Name(),
+		Port:       s.Port,
+		Protocol:   s.Protocol,
+		Egress:     s.Egress,
+		CIDRs:      ipv4,
+		SourceType: SourceType,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package feeds
+
+import (
+	"testing"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectionRule(t *testing.T) {
+	github := func(modify func(s *Selection)) Selection {
+		s := Selection{
+			Provider:  "github",
+			URLs:      []string{"file://testdata/github-meta.json"},
+			Selectors: []string{"hooks"},
+			Port:      443,
+			Protocol:  rule.ProtocolTCP,
+			Egress:    false,
+		}
+		modify(&s)
+		return s
+	}
+
+	tests := []struct {
+		name      string
+		selection Selection
+
+		expect rule.Rule
+		err    bool
+	}{
+		{
+			name:      "Valid",
+			selection: github(func(s *Selection) {}),
+			expect: rule.Rule{
+				Name:     "github:hooks",
+				Port:     443,
+				Protocol: rule.ProtocolTCP,
+				Egress:   false,
+				CIDRs: []string{
+					"192.30.252.0/22",
+					"185.199.108.0/22",
+					"140.82.112.0/20",
+					"143.55.64.0/20",
+				},
+				SourceType: SourceType,
+			},
+		},
+		{
+			name:      "UnknownProvider",
+			selection: github(func(s *Selection) { s.Provider = "gitlab" }),
+			err:       true,
+		},
+		{
+			name:      "NoMatches",
+			selection: github(func(s *Selection) { s.Selectors = []string{"nope"} }),
+			err:       true,
+		},
+		{
+			name: "OnlyIPv6",
+			selection: Selection{
+				Provider:  "cloudflare",
+				URLs:      []string{"file://testdata/cloudflare-ips-v4.txt", "file://testdata/cloudflare-ips-v6.txt"},
+				Selectors: []string{"ipv6"},
+				Port:      443,
+				Protocol:  rule.ProtocolTCP,
+			},
+			err: true,
+		},
+		{
+			name:      "NoProtocol",
+			selection: github(func(s *Selection) { s.Protocol = "" }),
+			err:       true,
+		},
+		{
+			name:      "UnsupportedProtocol",
+			selection: github(func(s *Selection) { s.Protocol = "icmp" }),
+			err:       true,
+		},
+		{
+			name:      "NoPort",
+			selection: github(func(s *Selection) { s.Port = 0 }),
+			err:       true,
+		},
+		{
+			name:      "InvalidPort",
+			selection: github(func(s *Selection) { s.Port = 65536 }),
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.selection.Rule()
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
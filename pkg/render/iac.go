@@ -0,0 +1,282 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// Names of the security group variable and parameter, which are declared
+// when Options.SecurityGroup is empty.
+const (
+	TerraformVariable       = "security_group_id"
+	CloudFormationParameter = "SecurityGroupId"
+)
+
+// maxCloudFormationResources is the largest number of resources in a
+// CloudFormation template.
+const maxCloudFormationResources = 500
+
+// nameSeparator matches the characters which separate the words of
+// resource names.
+var nameSeparator = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// resource is a security group rule for one CIDR.
+type resource struct {
+	// tfName and cfnName are the Terraform resource name and the
+	// CloudFormation logical ID.
+	tfName  string
+	cfnName string
+
+	rule rule.Rule
+	cidr string
+	ipv6 bool
+}
+
+// resources returns a resource for each CIDR of the rules, sorted by name.
+// Names are derived from the rule name, direction, protocol, port and CIDR,
+// so they stay the same while the rule and CIDR do.
+func resources(rules []rule.Rule, opts Options) ([]resource, error) {
+	tfNames := make(map[string]bool)
+	cfnNames := make(map[string]bool)
+	result := make([]resource, 0)
+
+	for _, r := range rules {
+		direction := "ingress"
+		if r.Egress {
+			direction = "egress"
+		}
+
+		for _, cidr := range unique(append([]string{}, r.CIDRs...)) {
+			words := []string{opts.name(), direction}
+			words = append(words, nameSeparator.Split(r.Name, -1)...)
+			words = append(words, r.Protocol, fmt.Sprint(r.Port))
+			words = append(words, nameSeparator.Split(cidr, -1)...)
+
+			res := resource{
+				tfName:  snakeCase(words),
+				cfnName: camelCase(words),
+				rule:    r,
+				cidr:    cidr,
+			}
+			ip, _, _ := net.ParseCIDR(cidr)
+			res.ipv6 = ip.To4() == nil
+
+			if tfNames[res.tfName] || cfnNames[res.cfnName] {
+				return nil, fmt.Errorf("rules have the same resource name %s", res.tfName)
+			}
+			tfNames[res.tfName] = true
+			cfnNames[res.cfnName] = true
+
+			result = append(result, res)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].tfName < result[j].tfName
+	})
+
+	return result, nil
+}
+
+// snakeCase joins words in lower case with underscores, such as
+// dsg_egress_api_example_com_tcp_443_203_0_113_10_32.
+func snakeCase(words []string) string {
+	parts := make([]string, 0, len(words))
+	for _, word := range words {
+		if word != "" {
+			parts = append(parts, strings.ToLower(word))
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// camelCase joins capitalized words, separating adjacent numbers with an x,
+// such as DsgEgressApiExampleComTcp443x203x0x113x10x32.
+func camelCase(words []string) string {
+	buf := &strings.Builder{}
+
+	last := ' '
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+
+		first := rune(word[0])
+		if unicode.IsDigit(last) && unicode.IsDigit(first) {
+			buf.WriteByte('x')
+		}
+		buf.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+
+		last = rune(word[len(word)-1])
+	}
+
+	return buf.String()
+}
+
+// resourceType returns the type of the Terraform resource for a rule.
+func (r *resource) resourceType() string {
+	if r.rule.Egress {
+		return "aws_vpc_security_group_egress_rule"
+	}
+	return "aws_vpc_security_group_ingress_rule"
+}
+
+// cidrAttribute returns the Terraform attribute of the resource's CIDR.
+func (r *resource) cidrAttribute() string {
+	if r.ipv6 {
+		return "cidr_ipv6"
+	}
+	return "cidr_ipv4"
+}
+
+// terraform renders Terraform HCL with a security group rule resource for each
+// CIDR.
+func terraform(rules []rule.Rule, opts Options) ([]byte, error) {
+	res, err := resources(rules, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sg := fmt.Sprintf("%q", opts.SecurityGroup)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, header)
+
+	if opts.SecurityGroup == "" {
+		sg = "var." + TerraformVariable
+
+		fmt.Fprintln(buf)
+		fmt.Fprintf(buf, "variable %q {\n", TerraformVariable)
+		fmt.Fprintln(buf, "  type = string")
+		fmt.Fprintln(buf, "}")
+	}
+
+	for _, r := range res {
+		fmt.Fprintln(buf)
+		fmt.Fprintf(buf, "resource %q %q {\n", r.resourceType(), r.tfName)
+		fmt.Fprintf(buf, "  security_group_id = %s\n", sg)
+		fmt.Fprintf(buf, "  description       = %q\n", r.rule.Name)
+		fmt.Fprintf(buf, "  ip_protocol       = %q\n", r.rule.Protocol)
+		fmt.Fprintf(buf, "  from_port         = %d\n", r.rule.Port)
+		fmt.Fprintf(buf, "  to_port           = %d\n", r.rule.Port)
+		fmt.Fprintf(buf, "  %-17s = %q\n", r.cidrAttribute(), r.cidr)
+		fmt.Fprintln(buf, "}")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// terraformJSON renders the Terraform resources as Terraform JSON.
+func terraformJSON(rules []rule.Rule, opts Options) ([]byte, error) {
+	res, err := resources(rules, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]interface{})
+
+	sg := opts.SecurityGroup
+	if sg == "" {
+		sg = "${var." + TerraformVariable + "}"
+		config["variable"] = map[string]interface{}{
+			TerraformVariable: map[string]string{"type": "string"},
+		}
+	}
+
+	resourceTypes := make(map[string]map[string]interface{})
+	for _, r := range res {
+		if resourceTypes[r.resourceType()] == nil {
+			resourceTypes[r.resourceType()] = make(map[string]interface{})
+		}
+
+		resourceTypes[r.resourceType()][r.tfName] = map[string]interface{}{
+			"security_group_id": sg,
+			"description":       r.rule.Name,
+			"ip_protocol":       r.rule.Protocol,
+			"from_port":         r.rule.Port,
+			"to_port":           r.rule.Port,
+			r.cidrAttribute():   r.cidr,
+		}
+	}
+	if len(resourceTypes) > 0 {
+		config["resource"] = resourceTypes
+	}
+
+	return marshal(config)
+}
+
+// cloudFormation renders a CloudFormation template with a security group
+// ingress or egress resource for each CIDR.
+func cloudFormation(rules []rule.Rule, opts Options) ([]byte, error) {
+	res, err := resources(rules, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, errors.New("a CloudFormation template needs at least one resource")
+	}
+	if len(res) > maxCloudFormationResources {
+		return nil, fmt.Errorf("%d resources exceed the CloudFormation limit of %d", len(res), maxCloudFormationResources)
+	}
+
+	template := map[string]interface{}{
+		"AWSTemplateFormatVersion": "2010-09-09",
+		"Description":              header[2:],
+	}
+
+	var sg interface{} = opts.SecurityGroup
+	if opts.SecurityGroup == "" {
+		sg = map[string]string{"Ref": CloudFormationParameter}
+		template["Parameters"] = map[string]interface{}{
+			CloudFormationParameter: map[string]string{"Type": "AWS::EC2::SecurityGroup::Id"},
+		}
+	}
+
+	cfnResources := make(map[string]interface{})
+	for _, r := range res {
+		resourceType := "AWS::EC2::SecurityGroupIngress"
+		if r.rule.Egress {
+			resourceType = "AWS::EC2::SecurityGroupEgress"
+		}
+
+		cidrProperty := "CidrIp"
+		if r.ipv6 {
+			cidrProperty = "CidrIpv6"
+		}
+
+		cfnResources[r.cfnName] = map[string]interface{}{
+			"Type": resourceType,
+			"Properties": map[string]interface{}{
+				"GroupId":     sg,
+				"Description": r.rule.Name,
+				"IpProtocol":  r.rule.Protocol,
+				"FromPort":    r.rule.Port,
+				"ToPort":      r.rule.Port,
+				cidrProperty:  r.cidr,
+			},
+		}
+	}
+	template["Resources"] = cfnResources
+
+	return marshal(template)
+}
+
+// marshal encodes a value as indented JSON. Maps are encoded with sorted keys,
+// so the output only changes when the value does.
+func marshal(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
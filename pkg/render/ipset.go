@@ -0,0 +1,34 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ipset renders an ipset restore file with one hash:net set per group. Each
+// set is filled under a temporary name and swapped in, so a set is never
+// partially loaded while it is in use.
+func ipset(groups []group, _ Options) []byte {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintln(buf, header)
+
+	for _, g := range groups {
+		family := "inet"
+		if g.ipv6 {
+			family = "inet6"
+		}
+		tmp := g.set + "_t"
+
+		fmt.Fprintf(buf, "create %s hash:net family %s -exist\n", g.set, family)
+		fmt.Fprintf(buf, "create %s hash:net family %s -exist\n", tmp, family)
+		fmt.Fprintf(buf, "flush %s\n", tmp)
+		for _, cidr := range g.cidrs {
+			fmt.Fprintf(buf, "add %s %s\n", tmp, cidr)
+		}
+		fmt.Fprintf(buf, "swap %s %s\n", tmp, g.set)
+		fmt.Fprintf(buf, "destroy %s\n", tmp)
+	}
+
+	return buf.Bytes()
+}
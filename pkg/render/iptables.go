@@ -0,0 +1,51 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// iptables renders iptables-restore input with one chain per hook, such as
+// DSG-OUTPUT. The chains accept traffic allowed by the rules and return
+// otherwise, so they are loaded with iptables-restore --noflush and jumped to
+// from the built-in chains, which decide what to do with the rest. iptables
+// only filters IPv4, so IPv6 CIDRs are skipped.
+func iptables(groups []group, opts Options) []byte {
+	buf := &bytes.Buffer{}
+	prefix := strings.ToUpper(opts.name())
+
+	fmt.Fprintln(buf, header)
+	fmt.Fprintln(buf, "*filter")
+
+	v4 := make([]group, 0, len(groups))
+	for _, g := range groups {
+		if !g.ipv6 {
+			v4 = append(v4, g)
+		}
+	}
+
+	chains := hooks(v4, opts.Forward)
+	for _, hook := range chains {
+		fmt.Fprintf(buf, ":%s-%s - [0:0]\n", prefix, strings.ToUpper(hook.name))
+	}
+
+	for _, hook := range chains {
+		chain := prefix + "-" + strings.ToUpper(hook.name)
+
+		for _, g := range hook.groups {
+			addr := "-s"
+			if g.egress {
+				addr = "-d"
+			}
+
+			for _, cidr := range g.cidrs {
+				fmt.Fprintf(buf, "-A %s %s %s -p %s -m %s --dport %d -j ACCEPT\n", chain, addr, cidr, g.protocol, g.protocol, g.port)
+			}
+		}
+	}
+
+	fmt.Fprintln(buf, "COMMIT")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,302 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// RuleAnnotation is the annotation of network policies with the name of the
+// rules they were rendered from.
+const RuleAnnotation = "dynamic-security-groups/rule"
+
+// maxPolicyName is the longest name of a network policy.
+const maxPolicyName = 253
+
+// invalidPolicyName matches runs of characters which are not valid in the
+// name of a network policy.
+var invalidPolicyName = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// policy is the rules with one name, which are rendered as one network
+// policy.
+type policy struct {
+	name  string
+	rule  string
+	rules []rule.Rule
+}
+
+// policies groups rules by name into policies, sorted by name. Rules without
+// CIDRs are skipped unless keep returns true, because an empty list of peers
+// would allow traffic from and to anywhere.
+func policies(rules []rule.Rule, opts Options, keep func(r rule.Rule) bool) ([]policy, error) {
+	prefix := strings.Replace(strings.ToLower(opts.name()), "_", "-", -1)
+
+	byRule := make(map[string]*policy)
+	byName := make(map[string]string)
+
+	for _, r := range rules {
+		if len(r.CIDRs) == 0 && !keep(r) {
+			continue
+		}
+
+		p, ok := byRule[r.Name]
+		if !ok {
+			name := policyName(prefix, r.Name)
+			if other, ok := byName[name]; ok {
+				return nil, fmt.Errorf("rules %s and %s have the same policy name %s", other, r.Name, name)
+			}
+			byName[name] = r.Name
+
+			p = &policy{name: name, rule: r.Name}
+			byRule[r.Name] = p
+		}
+
+		r.CIDRs = unique(append([]string{}, r.CIDRs...))
+		p.rules = append(p.rules, r)
+	}
+
+	result := make([]policy, 0, len(byRule))
+	for _, p := range byRule {
+		result = append(result, *p)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+
+	return result, nil
+}
+
+// policyName returns a valid network policy name for a rule name, such as
+// dsg-api.example.com.
+func policyName(prefix, name string) string {
+	name = strings.Replace(strings.ToLower(name), "*", "wildcard", -1)
+	name = prefix + "-" + invalidPolicyName.ReplaceAllString(name, "-")
+
+	if len(name) > maxPolicyName {
+		name = name[:maxPolicyName]
+	}
+
+	return strings.TrimRight(name, ".-")
+}
+
+// metadata writes the metadata of a network policy.
+func metadata(buf *bytes.Buffer, p policy, opts Options) {
+	fmt.Fprintf(buf, "metadata:\n")
+	fmt.Fprintf(buf, "  name: %s\n", p.name)
+	if opts.Namespace != "" {
+		fmt.Fprintf(buf, "  namespace: %s\n", opts.Namespace)
+	}
+	fmt.Fprintf(buf, "  labels:\n")
+	fmt.Fprintf(buf, "    app.kubernetes.io/managed-by: %s\n", opts.name())
+	fmt.Fprintf(buf, "  annotations:\n")
+	fmt.Fprintf(buf, "    %s: %q\n", RuleAnnotation, p.rule)
+}
+
+// selector writes a label selector, which selects everything if labels is
+// empty.
+func selector(buf *bytes.Buffer, key string, labels map[string]string) {
+	if len(labels) == 0 {
+		fmt.Fprintf(buf, "  %s: {}\n", key)
+		return
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(buf, "  %s:\n", key)
+	fmt.Fprintf(buf, "    matchLabels:\n")
+	for _, k := range keys {
+		fmt.Fprintf(buf, "      %s: %q\n", k, labels[k])
+	}
+}
+
+// networkPolicies renders one Kubernetes NetworkPolicy per rule name, with an
+// ipBlock peer per CIDR.
+func networkPolicies(rules []rule.Rule, opts Options) ([]byte, error) {
+	ps, err := policies(rules, opts, func(rule.Rule) bool {
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, header)
+
+	for _, p := range ps {
+		fmt.Fprintln(buf, "---")
+		fmt.Fprintln(buf, "apiVersion: networking.k8s.io/v1")
+		fmt.Fprintln(buf, "kind: NetworkPolicy")
+		metadata(buf, p, opts)
+		fmt.Fprintln(buf, "spec:")
+		selector(buf, "podSelector", opts.PodSelector)
+
+		ingress, egress := split(p.rules)
+
+		fmt.Fprintln(buf, "  policyTypes:")
+		if len(ingress) > 0 {
+			fmt.Fprintln(buf, "  - Ingress")
+		}
+		if len(egress) > 0 {
+			fmt.Fprintln(buf, "  - Egress")
+		}
+
+		for _, direction := range []struct {
+			key, peers string
+			rules      []rule.Rule
+		}{
+			{"ingress", "from", ingress},
+			{"egress", "to", egress},
+		} {
+			if len(direction.rules) == 0 {
+				continue
+			}
+
+			fmt.Fprintf(buf, "  %s:\n", direction.key)
+			for _, r := range direction.rules {
+				fmt.Fprintf(buf, "  - %s:\n", direction.peers)
+				for _, cidr := range r.CIDRs {
+					fmt.Fprintf(buf, "    - ipBlock:\n")
+					fmt.Fprintf(buf, "        cidr: %q\n", cidr)
+				}
+				fmt.Fprintf(buf, "    ports:\n")
+				fmt.Fprintf(buf, "    - protocol: %s\n", strings.ToUpper(r.Protocol))
+				fmt.Fprintf(buf, "      port: %d\n", r.Port)
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ciliumPolicies renders one CiliumNetworkPolicy per rule name. Egress to DNS
+// names is allowed with toFQDNs, along with the DNS lookups of the names, so
+// the policy follows DNS changes between renders. Other rules are allowed with
+// their CIDRs.
+func ciliumPolicies(rules []rule.Rule, opts Options) ([]byte, error) {
+	ps, err := policies(rules, opts, fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, header)
+
+	for _, p := range ps {
+		fmt.Fprintln(buf, "---")
+		fmt.Fprintln(buf, "apiVersion: cilium.io/v2")
+		fmt.Fprintln(buf, "kind: CiliumNetworkPolicy")
+		metadata(buf, p, opts)
+		fmt.Fprintln(buf, "spec:")
+		selector(buf, "endpointSelector", opts.PodSelector)
+
+		ingress, egress := split(p.rules)
+
+		if len(ingress) > 0 {
+			fmt.Fprintln(buf, "  ingress:")
+			for _, r := range ingress {
+				fmt.Fprintln(buf, "  - fromCIDR:")
+				for _, cidr := range r.CIDRs {
+					fmt.Fprintf(buf, "    - %q\n", cidr)
+				}
+				ciliumPorts(buf, r)
+			}
+		}
+
+		if len(egress) > 0 {
+			fmt.Fprintln(buf, "  egress:")
+			for _, r := range egress {
+				if fqdn(r) {
+					continue
+				}
+
+				fmt.Fprintln(buf, "  - toCIDR:")
+				for _, cidr := range r.CIDRs {
+					fmt.Fprintf(buf, "    - %q\n", cidr)
+				}
+				ciliumPorts(buf, r)
+			}
+
+			for _, r := range egress {
+				if !fqdn(r) {
+					continue
+				}
+
+				fmt.Fprintln(buf, "  - toFQDNs:")
+				fmt.Fprintf(buf, "    - %s: %q\n", fqdnMatch(r.Name), r.Name)
+				ciliumPorts(buf, r)
+			}
+
+			if names := fqdnNames(egress); len(names) > 0 {
+				fmt.Fprintln(buf, "  - toEndpoints:")
+				fmt.Fprintln(buf, "    - matchLabels:")
+				fmt.Fprintln(buf, "        k8s:io.kubernetes.pod.namespace: kube-system")
+				fmt.Fprintln(buf, "        k8s-app: kube-dns")
+				fmt.Fprintln(buf, "    toPorts:")
+				fmt.Fprintln(buf, "    - ports:")
+				fmt.Fprintln(buf, "      - port: \"53\"")
+				fmt.Fprintln(buf, "        protocol: ANY")
+				fmt.Fprintln(buf, "      rules:")
+				fmt.Fprintln(buf, "        dns:")
+				for _, name := range names {
+					fmt.Fprintf(buf, "        - %s: %q\n", fqdnMatch(name), name)
+				}
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ciliumPorts writes the port of a Cilium rule.
+func ciliumPorts(buf *bytes.Buffer, r rule.Rule) {
+	fmt.Fprintln(buf, "    toPorts:")
+	fmt.Fprintln(buf, "    - ports:")
+	fmt.Fprintf(buf, "      - port: \"%d\"\n", r.Port)
+	fmt.Fprintf(buf, "        protocol: %s\n", strings.ToUpper(r.Protocol))
+}
+
+// fqdn returns true if a rule allows egress to a DNS name.
+func fqdn(r rule.Rule) bool {
+	return r.Egress && r.SourceType == rule.SourceTypeDNS
+}
+
+// fqdnMatch returns the Cilium selector for a DNS name, which is a pattern if
+// the name has a wildcard.
+func fqdnMatch(name string) string {
+	if strings.Contains(name, "*") {
+		return "matchPattern"
+	}
+	return "matchName"
+}
+
+// fqdnNames returns the unique DNS names of rules, sorted.
+func fqdnNames(rules []rule.Rule) []string {
+	names := make([]string, 0)
+	for _, r := range rules {
+		if fqdn(r) {
+			names = append(names, r.Name)
+		}
+	}
+	return unique(names)
+}
+
+// split returns the ingress and egress rules.
+func split(rules []rule.Rule) (ingress, egress []rule.Rule) {
+	for _, r := range rules {
+		if r.Egress {
+			egress = append(egress, r)
+		} else {
+			ingress = append(ingress, r)
+		}
+	}
+	return ingress, egress
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// nftables renders an nftables ruleset, which is loaded atomically with
+// nft -f. The ruleset replaces its own table, and filters each hook with
+// rules with a drop policy, so only established traffic and traffic allowed
+// by the rules pass. Hooks without rules are not filtered.
+func nftables(groups []group, opts Options) []byte {
+	name := opts.name()
+	blocks := make([]string, 0)
+
+	for _, g := range groups {
+		addrType := "ipv4_addr"
+		if g.ipv6 {
+			addrType = "ipv6_addr"
+		}
+
+		block := &bytes.Buffer{}
+		fmt.Fprintf(block, "\tset %s {\n", g.set)
+		fmt.Fprintf(block, "\t\ttype %s\n", addrType)
+		fmt.Fprintf(block, "\t\tflags interval\n")
+		// CIDRs of different rules may overlap, which intervals only allow
+		// when they are merged.
+		fmt.Fprintf(block, "\t\tauto-merge\n")
+		fmt.Fprintf(block, "\t\telements = {\n\t\t\t%s\n\t\t}\n", strings.Join(g.cidrs, ",\n\t\t\t"))
+		fmt.Fprintf(block, "\t}\n")
+		blocks = append(blocks, block.String())
+	}
+
+	for _, hook := range hooks(groups, opts.Forward) {
+		block := &bytes.Buffer{}
+		fmt.Fprintf(block, "\tchain %s {\n", hook.name)
+		fmt.Fprintf(block, "\t\ttype filter hook %s priority 0; policy drop;\n", hook.name)
+		fmt.Fprintf(block, "\t\tct state established,related accept\n")
+		switch hook.name {
+		case hookInput:
+			fmt.Fprintf(block, "\t\tiif \"lo\" accept\n")
+		case hookOutput:
+			fmt.Fprintf(block, "\t\toif \"lo\" accept\n")
+		}
+
+		for _, g := range hook.groups {
+			family, addr := "ip", "saddr"
+			if g.ipv6 {
+				family = "ip6"
+			}
+			if g.egress {
+				addr = "daddr"
+			}
+
+			fmt.Fprintf(block, "\t\t%s %s @%s %s dport %d accept\n", family, addr, g.set, g.protocol, g.port)
+		}
+
+		fmt.Fprintf(block, "\t}\n")
+		blocks = append(blocks, block.String())
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, header)
+	fmt.Fprintf(buf, "table inet %s\n", name)
+	fmt.Fprintf(buf, "delete table inet %s\n\n", name)
+	fmt.Fprintf(buf, "table inet %s {\n", name)
+	fmt.Fprint(buf, strings.Join(blocks, "\n"))
+	fmt.Fprintln(buf, "}")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,283 @@
+// Package render renders resolved rules as host firewall configuration, such
+// as an nftables ruleset, an ipset restore file or iptables-restore input, as
+// Kubernetes network policies, and as Terraform and CloudFormation resources,
+// so the same rules which are applied to security groups can be enforced on
+// NAT instances, bastions and pods, or committed to infrastructure as code.
+package render
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// Formats.
+const (
+	FormatNftables       = "nftables"
+	FormatIPSet          = "ipset"
+	FormatIPTables       = "iptables"
+	FormatNetworkPolicy  = "networkpolicy"
+	FormatCilium         = "cilium"
+	FormatTerraform      = "terraform"
+	FormatTerraformJSON  = "terraform-json"
+	FormatCloudFormation = "cloudformation"
+)
+
+// DefaultName is the default name of the rendered table, chains and sets.
+const DefaultName = "dsg"
+
+// header is the first line of every rendered file.
+const header = "# Generated by dsg. Do not edit."
+
+// namePattern matches valid names. ipset names are limited to 31 characters,
+// which leaves 10 for the name.
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,9}$`)
+
+// renderer renders rules in one format.
+type renderer func(rules []rule.Rule, opts Options) ([]byte, error)
+
+// renderers render each format.
+var renderers = map[string]renderer{
+	FormatNftables:       host(nftables),
+	FormatIPSet:          host(ipset),
+	FormatIPTables:       host(iptables),
+	FormatNetworkPolicy:  networkPolicies,
+	FormatCilium:         ciliumPolicies,
+	FormatTerraform:      terraform,
+	FormatTerraformJSON:  terraformJSON,
+	FormatCloudFormation: cloudFormation,
+}
+
+// host adapts a host firewall renderer, which renders the CIDRs of rules
+// grouped into sets.
+func host(render func(groups []group, opts Options) []byte) renderer {
+	return func(rules []rule.Rule, opts Options) ([]byte, error) {
+		return render(groupRules(rules, opts.name()), opts), nil
+	}
+}
+
+// Formats returns the supported formats.
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Options configure rendering.
+type Options struct {
+	// Name names the nftables table, and prefixes the chains, sets and
+	// network policies. Defaults to dsg.
+	Name string
+
+	// Forward filters traffic forwarded by the host, as on a NAT instance,
+	// instead of traffic to and from the host itself.
+	Forward bool
+
+	// Namespace is the namespace of network policies. The namespace is
+	// omitted if empty.
+	Namespace string
+
+	// PodSelector are the labels of the pods which network policies apply
+	// to. Network policies apply to every pod in the namespace if empty.
+	PodSelector map[string]string
+
+	// SecurityGroup is the ID of the security group of Terraform and
+	// CloudFormation resources. A variable or parameter is declared for it
+	// if empty.
+	SecurityGroup string
+}
+
+// name returns the name, or the default name.
+func (o *Options) name() string {
+	if o.Name == "" {
+		return DefaultName
+	}
+	return o.Name
+}
+
+// Render renders rules in a format.
+func Render(format string, rules []rule.Rule, opts Options) ([]byte, error) {
+	renderer, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	if !namePattern.MatchString(opts.name()) {
+		return nil, fmt.Errorf("invalid name %q: must match %s", opts.name(), namePattern)
+	}
+
+	for _, r := range rules {
+		if err := validate(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return renderer(rules, opts)
+}
+
+// validate returns an error if a rule cannot be rendered.
+func validate(r rule.Rule) error {
+	if r.Protocol != rule.ProtocolTCP && r.Protocol != rule.ProtocolUDP {
+		return fmt.Errorf("unsupported protocol %q for %s", r.Protocol, r.Name)
+	}
+	if r.Port < 1 || r.Port > 65535 {
+		return fmt.Errorf("invalid port %d for %s", r.Port, r.Name)
+	}
+
+	for _, cidr := range r.CIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %q for %s: %v", cidr, r.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// group is the CIDRs of one address family which are allowed with the same
+// direction, protocol and port.
+type group struct {
+	// set is the name of the group's set.
+	set string
+
+	egress   bool
+	protocol string
+	port     int
+	ipv6     bool
+	cidrs    []string
+}
+
+// groupRules groups the CIDRs of validated rules into sets. The groups and
+// their CIDRs are sorted, so the output only changes when the rules do.
+func groupRules(rules []rule.Rule, name string) []group {
+	byKey := make(map[string]*group)
+
+	for _, r := range rules {
+		for _, cidr := range r.CIDRs {
+			ip, _, _ := net.ParseCIDR(cidr)
+
+			g := group{egress: r.Egress, protocol: r.Protocol, port: r.Port, ipv6: ip.To4() == nil}
+			g.set = g.name(name)
+
+			if existing, ok := byKey[g.set]; ok {
+				existing.cidrs = append(existing.cidrs, cidr)
+				continue
+			}
+			g.cidrs = []string{cidr}
+			byKey[g.set] = &g
+		}
+	}
+
+	groups := make([]group, 0, len(byKey))
+	for _, g := range byKey {
+		g.cidrs = unique(g.cidrs)
+		groups = append(groups, *g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].set < groups[j].set
+	})
+
+	return groups
+}
+
+// name returns the name of the group's set, such as dsg_out_tcp_443_v4.
+func (g *group) name(prefix string) string {
+	direction, family := "in", "v4"
+	if g.egress {
+		direction = "out"
+	}
+	if g.ipv6 {
+		family = "v6"
+	}
+
+	return fmt.Sprintf("%s_%s_%s_%d_%s", prefix, direction, g.protocol, g.port, family)
+}
+
+// unique returns the sorted, unique strings.
+func unique(values []string) []string {
+	sort.Strings(values)
+
+	result := make([]string, 0, len(values))
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+// Netfilter hooks.
+const (
+	hookInput   = "input"
+	hookOutput  = "output"
+	hookForward = "forward"
+)
+
+// hook is a netfilter hook, and the groups it filters.
+type hook struct {
+	name   string
+	groups []group
+}
+
+// hooks assigns groups to the hooks which filter them: ingress to input and
+// egress to output, or every group to forward. Hooks without groups are
+// omitted.
+func hooks(groups []group, forward bool) []hook {
+	result := make([]hook, 0)
+
+	for _, name := range []string{hookInput, hookOutput, hookForward} {
+		h := hook{name: name}
+		for _, g := range groups {
+			switch {
+			case forward && name == hookForward,
+				!forward && name == hookInput && !g.egress,
+				!forward && name == hookOutput && g.egress:
+				h.groups = append(h.groups, g)
+			}
+		}
+
+		if len(h.groups) > 0 {
+			result = append(result, h)
+		}
+	}
+
+	return result
+}
+
+// WriteFile writes data to a file atomically, so a firewall never loads a
+// partial file.
+func WriteFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,154 @@
+package render
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// testRules cover both directions, protocols, address families and DNS names,
+// with duplicate CIDRs across rules.
+var testRules = []rule.Rule{
+	{Name: "api.example.com", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.20/32", "203.0.113.10/32"}, SourceType: rule.SourceTypeDNS},
+	{Name: "api.example.com", Port: 8443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.20/32", "203.0.113.10/32"}, SourceType: rule.SourceTypeDNS},
+	{Name: "*.cdn.example.com", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, SourceType: rule.SourceTypeDNS},
+	{Name: "S3", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"52.216.0.0/15", "203.0.113.10/32", "2600:1fa0::/32"}},
+	{Name: "ntp", Port: 123, Protocol: rule.ProtocolUDP, Egress: true, CIDRs: []string{"169.254.169.123/32"}},
+	{Name: "office", Port: 22, Protocol: rule.ProtocolTCP, CIDRs: []string{"198.51.100.0/24"}},
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		rules  []rule.Rule
+		opts   Options
+
+		expectErr bool
+	}{
+		{name: "nftables", format: FormatNftables, rules: testRules},
+		{name: "nftables-forward", format: FormatNftables, rules: testRules, opts: Options{Name: "nat", Forward: true}},
+		{name: "ipset", format: FormatIPSet, rules: testRules},
+		{name: "iptables", format: FormatIPTables, rules: testRules},
+		{name: "iptables-forward", format: FormatIPTables, rules: testRules, opts: Options{Forward: true}},
+		{name: "networkpolicy", format: FormatNetworkPolicy, rules: testRules},
+		{
+			name:   "networkpolicy-selector",
+			format: FormatNetworkPolicy,
+			rules:  testRules,
+			opts:   Options{Name: "egress", Namespace: "web", PodSelector: map[string]string{"app": "api", "tier": "backend"}},
+		},
+		{name: "cilium", format: FormatCilium, rules: testRules},
+		{name: "terraform", format: FormatTerraform, rules: testRules},
+		{name: "terraform-sg", format: FormatTerraform, rules: testRules, opts: Options{SecurityGroup: "sg-0123456789abcdef0"}},
+		{name: "terraform-json", format: FormatTerraformJSON, rules: testRules},
+		{name: "cloudformation", format: FormatCloudFormation, rules: testRules},
+		{name: "cloudformation-sg", format: FormatCloudFormation, rules: testRules, opts: Options{SecurityGroup: "sg-0123456789abcdef0"}},
+		{
+			name:   "CloudFormationEmpty",
+			format: FormatCloudFormation,
+
+			expectErr: true,
+		},
+		{
+			name:   "ResourceNameCollision",
+			format: FormatTerraform,
+			rules: []rule.Rule{
+				{Name: "api.example.com", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32"}},
+				{Name: "api-example-com", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32"}},
+			},
+
+			expectErr: true,
+		},
+		{
+			name:   "PolicyNameCollision",
+			format: FormatNetworkPolicy,
+			rules: []rule.Rule{
+				{Name: "api.example.com", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32"}},
+				{Name: "API.example.com", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32"}},
+			},
+
+			expectErr: true,
+		},
+		{
+			name:   "UnknownFormat",
+			format: "pf",
+			rules:  testRules,
+
+			expectErr: true,
+		},
+		{
+			name:   "InvalidName",
+			format: FormatIPSet,
+			rules:  testRules,
+			opts:   Options{Name: "egress-firewall"},
+
+			expectErr: true,
+		},
+		{
+			name:   "UnsupportedProtocol",
+			format: FormatNftables,
+			rules:  []rule.Rule{{Name: "ping", Protocol: rule.ProtoclICMP, CIDRs: []string{"10.0.0.0/8"}}},
+
+			expectErr: true,
+		},
+		{
+			name:   "InvalidCIDR",
+			format: FormatNftables,
+			rules:  []rule.Rule{{Name: "bad", Port: 443, Protocol: rule.ProtocolTCP, CIDRs: []string{"10.0.0.0"}}},
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := Render(test.format, test.rules, test.opts)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			golden := filepath.Join("testdata", test.name+".golden")
+			if *update {
+				assert.NoError(t, ioutil.WriteFile(golden, out, 0644))
+			}
+
+			expected, err := ioutil.ReadFile(golden)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(out))
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "render")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "dsg.nft")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("old"), 0600))
+
+	assert.NoError(t, WriteFile(path, []byte("new")))
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
@@ -0,0 +1,68 @@
+package rule
+
+import (
+	"log"
+
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+)
+
+// Options configures how rules are applied to a security group.
+type Options struct {
+	// Owner namespaces the rules managed by the caller, so several
+	// deployments can share a security group. Rules of other owners are
+	// never modified.
+	Owner string `json:"owner"`
+
+	// MaxShrinkPercent is the largest percentage of existing rules belonging
+	// to the owner which may be removed in one invocation. Zero disables the
+	// check.
+	MaxShrinkPercent float64 `json:"maxShrinkPercent"`
+
+	// UseTags tracks rules with security group rule tags instead of only
+	// their descriptions. Descriptions are used if the EC2 API does not
+	// support security group rule tags.
+	UseTags bool `json:"useTags"`
+
+	// AllowEmptyDomains lets domain lists and rule groups be emptied when
+	// there are no DNS rules. Otherwise they are left unchanged and an
+	// error is returned, since they own every domain they contain.
+	AllowEmptyDomains bool `json:"allowEmptyDomains"`
+}
+
+// Validate returns an error if the options are invalid.
+func (o *Options) Validate() error {
+	return ValidateOwner(o.Owner)
+}
+
+// Apply adds rules to a security group and removes the stale rules belonging
+// to the owner. The rules must already be resolved.
+func Apply(rules []Rule, sgid string, opts Options, ec2Client ec2iface.EC2API) error {
+	if opts.UseTags {
+		err := SyncTagged(rules, sgid, opts, ec2Client)
+		if err != ErrTagsUnsupported {
+			return err
+		}
+		log.Printf("WARNING: %v; falling back to rule descriptions for %s", err, sgid)
+	}
+
+	sg, err := awshelpers.DescribeSecurityGroup(sgid, ec2Client)
+	if err != nil {
+		return err
+	}
+
+	if InSync(rules, sg, opts.Owner) {
+		log.Printf("Security group %s is already in sync", sgid)
+		return nil
+	}
+
+	if err := CheckShrink(rules, sg, opts.Owner, opts.MaxShrinkPercent); err != nil {
+		return err
+	}
+
+	if err := Add(rules, sg, opts.Owner, ec2Client); err != nil {
+		return err
+	}
+
+	return Cleanup(rules, sg, opts.Owner, ec2Client)
+}
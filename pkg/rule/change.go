@@ -0,0 +1,179 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// Change actions.
+const (
+	ChangeAdd    = "add"
+	ChangeRemove = "remove"
+	ChangeUpdate = "update"
+)
+
+// Directions of changes to targets with ingress and egress rules.
+const (
+	DirectionIngress = "ingress"
+	DirectionEgress  = "egress"
+)
+
+// Change is a change which applying rules makes to a target.
+type Change struct {
+	// Target is the ID of the target.
+	Target string `json:"target"`
+
+	// Action is add, remove or update. Existing rules are updated when
+	// their descriptions or tags are rewritten, such as when an owner
+	// claims a legacy rule.
+	Action string `json:"action"`
+
+	// Direction, Protocol and Port are set for targets with rules, such as
+	// security groups.
+	Direction string `json:"direction,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Port      int    `json:"port,omitempty"`
+
+	// Value is the CIDR or domain.
+	Value string `json:"value"`
+
+	// Description is the description of the rule or entry, if any.
+	Description string `json:"description,omitempty"`
+}
+
+// direction returns the direction of a rule.
+func direction(egress bool) string {
+	if egress {
+		return DirectionEgress
+	}
+	return DirectionIngress
+}
+
+// SortChanges sorts changes by target, action, direction, protocol, port and
+// value, so plans are printed in a stable order.
+func SortChanges(changes []Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		switch {
+		case a.Target != b.Target:
+			return a.Target < b.Target
+		case a.Action != b.Action:
+			return a.Action < b.Action
+		case a.Direction != b.Direction:
+			return a.Direction < b.Direction
+		case a.Protocol != b.Protocol:
+			return a.Protocol < b.Protocol
+		case a.Port != b.Port:
+			return a.Port < b.Port
+		default:
+			return a.Value < b.Value
+		}
+	})
+}
+
+// ruleChange returns a change to a CIDR of a rule.
+func ruleChange(target, action string, rule Rule, cidr, description string) Change {
+	return Change{
+		Target:      target,
+		Action:      action,
+		Direction:   direction(rule.Egress),
+		Protocol:    rule.Protocol,
+		Port:        rule.Port,
+		Value:       cidr,
+		Description: description,
+	}
+}
+
+// permissionChanges returns a change for each CIDR in a list of permissions.
+func permissionChanges(target, action string, permissions []*ec2.IpPermission, egress bool) []Change {
+	changes := make([]Change, 0)
+	for _, permission := range permissions {
+		for _, ipRange := range permission.IpRanges {
+			changes = append(changes, Change{
+				Target:      target,
+				Action:      action,
+				Direction:   direction(egress),
+				Protocol:    aws.StringValue(permission.IpProtocol),
+				Port:        int(aws.Int64Value(permission.FromPort)),
+				Value:       aws.StringValue(ipRange.CidrIp),
+				Description: aws.StringValue(ipRange.Description),
+			})
+		}
+	}
+	return changes
+}
+
+// securityGroupRuleChange returns a change to an existing security group rule.
+func securityGroupRuleChange(target, action string, sgr *ec2.SecurityGroupRule) Change {
+	value := aws.StringValue(sgr.CidrIpv4)
+	if sgr.CidrIpv6 != nil {
+		value = *sgr.CidrIpv6
+	}
+
+	return Change{
+		Target:      target,
+		Action:      action,
+		Direction:   direction(aws.BoolValue(sgr.IsEgress)),
+		Protocol:    aws.StringValue(sgr.IpProtocol),
+		Port:        int(aws.Int64Value(sgr.FromPort)),
+		Value:       value,
+		Description: aws.StringValue(sgr.Description),
+	}
+}
+
+// entryChanges returns the changes in a plan of entries.
+func entryChanges(target string, p *Plan) []Change {
+	changes := make([]Change, 0, len(p.Add)+len(p.Remove))
+	for _, entry := range p.Add {
+		changes = append(changes, Change{
+			Target:      target,
+			Action:      ChangeAdd,
+			Value:       entry.CIDR,
+			Description: aws.StringValue(entry.Description),
+		})
+	}
+	for _, entry := range p.Remove {
+		changes = append(changes, Change{
+			Target:      target,
+			Action:      ChangeRemove,
+			Value:       entry.CIDR,
+			Description: aws.StringValue(entry.Description),
+		})
+	}
+	return changes
+}
+
+// domainChanges returns the changes which add and remove domains.
+func domainChanges(target string, add, remove []string) []Change {
+	changes := make([]Change, 0, len(add)+len(remove))
+	for _, domain := range add {
+		changes = append(changes, Change{Target: target, Action: ChangeAdd, Value: domain})
+	}
+	for _, domain := range remove {
+		changes = append(changes, Change{Target: target, Action: ChangeRemove, Value: domain})
+	}
+	return changes
+}
+
+// aclChange returns a change to a numbered network ACL entry.
+func aclChange(target, action string, number int64, egress bool, entry aclEntry) Change {
+	protocol := "all"
+	for name, value := range aclProtocols {
+		if value == entry.protocol {
+			protocol = name
+		}
+	}
+
+	return Change{
+		Target:      target,
+		Action:      action,
+		Direction:   direction(egress),
+		Protocol:    protocol,
+		Port:        int(entry.port),
+		Value:       entry.cidr,
+		Description: fmt.Sprintf("rule %d (%s)", number, entry.action),
+	}
+}
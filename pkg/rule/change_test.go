@@ -0,0 +1,235 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanges(t *testing.T) {
+	rules := []Rule{
+		{Name: "api.foo.com", Port: 443, Protocol: ProtocolTCP, Egress: true, CIDRs: []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.3/32"}},
+	}
+
+	sg := &ec2.SecurityGroup{
+		GroupId: aws.String("sg-123"),
+		IpPermissionsEgress: []*ec2.IpPermission{
+			{
+				FromPort:   aws.Int64(443),
+				ToPort:     aws.Int64(443),
+				IpProtocol: aws.String(ProtocolTCP),
+				IpRanges: []*ec2.IpRange{
+					{CidrIp: aws.String("10.0.0.1/32"), Description: aws.String("AUTOGENERATED[payments]: api.foo.com")},
+					{CidrIp: aws.String("10.0.0.2/32"), Description: aws.String("AUTOGENERATED: api.foo.com")},
+					{CidrIp: aws.String("10.0.0.9/32"), Description: aws.String("AUTOGENERATED[payments]: api.foo.com")},
+					{CidrIp: aws.String("10.0.0.8/32"), Description: aws.String("AUTOGENERATED[search]: api.bar.com")},
+				},
+			},
+		},
+	}
+
+	changes := changes(rules, sg, "payments")
+	SortChanges(changes)
+
+	assert.Equal(t, []Change{
+		{Target: "sg-123", Action: ChangeAdd, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.3/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+		{Target: "sg-123", Action: ChangeRemove, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.9/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+		{Target: "sg-123", Action: ChangeUpdate, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.2/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+	}, changes)
+}
+
+func TestSecurityGroupPlan(t *testing.T) {
+	rules := []Rule{
+		{Name: "api.foo.com", Port: 443, Protocol: ProtocolTCP, Egress: true, CIDRs: []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.3/32"}},
+	}
+
+	existing := []*ec2.SecurityGroupRule{
+		securityGroupRule("sgr-1", "10.0.0.1/32", "AUTOGENERATED[payments]: api.foo.com", map[string]string{TagOwner: "payments"}),
+		securityGroupRule("sgr-2", "10.0.0.9/32", "AUTOGENERATED[payments]: api.foo.com", map[string]string{TagOwner: "payments"}),
+		securityGroupRule("sgr-3", "10.0.0.2/32", "AUTOGENERATED: api.foo.com", nil),
+		securityGroupRule("sgr-4", "10.0.0.8/32", "AUTOGENERATED[search]: api.bar.com", map[string]string{TagOwner: "search"}),
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+
+		expectErr     bool
+		expectChanges []Change
+	}{
+		{
+			name: "Changes",
+			opts: Options{Owner: "payments", UseTags: true},
+
+			expectChanges: []Change{
+				{Target: "sg-123", Action: ChangeAdd, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.3/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+				{Target: "sg-123", Action: ChangeRemove, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.9/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+				{Target: "sg-123", Action: ChangeUpdate, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.2/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+			},
+		},
+		{
+			name: "ExceedsMaxShrink",
+			opts: Options{Owner: "payments", UseTags: true, MaxShrinkPercent: 10},
+
+			expectErr: true,
+			expectChanges: []Change{
+				{Target: "sg-123", Action: ChangeAdd, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.3/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+				{Target: "sg-123", Action: ChangeRemove, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.9/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+				{Target: "sg-123", Action: ChangeUpdate, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.2/32", Description: "AUTOGENERATED[payments]: api.foo.com"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &mockEC2Client{SecurityGroupRules: existing}
+			target := &SecurityGroup{ID: "sg-123", Client: client}
+
+			changes, err := target.Plan(rules, test.opts)
+			SortChanges(changes)
+
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectChanges, changes)
+			assert.Empty(t, client.CreateTagsCalls)
+			assert.Empty(t, client.ModifySecurityGroupRulesCalls)
+		})
+	}
+}
+
+func TestPrefixListPlan(t *testing.T) {
+	sleep = func() {}
+
+	client := newFakePrefixListClient(10, map[string]string{
+		"10.0.1.0/24": "AUTOGENERATED: S3",
+		"10.0.2.0/24": "AUTOGENERATED: S3",
+		"10.0.3.0/24": "AUTOGENERATED[other]: S3",
+	})
+	target := &PrefixList{ID: "pl-123", Client: client}
+
+	changes, err := target.Plan([]Rule{
+		{Name: "S3", CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24", "2001:db8::/32"}},
+	}, Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Target: "pl-123", Action: ChangeAdd, Value: "10.0.0.0/24", Description: "AUTOGENERATED: S3"},
+		{Target: "pl-123", Action: ChangeRemove, Value: "10.0.2.0/24", Description: "AUTOGENERATED: S3"},
+	}, changes)
+	assert.Zero(t, client.modifies)
+}
+
+func TestNetworkACLPlan(t *testing.T) {
+	client := &fakeNetworkACLClient{entries: []*ec2.NetworkAclEntry{
+		{
+			CidrBlock:  aws.String("10.0.9.0/24"),
+			Egress:     aws.Bool(true),
+			PortRange:  &ec2.PortRange{From: aws.Int64(443), To: aws.Int64(443)},
+			Protocol:   aws.String("6"),
+			RuleAction: aws.String(ec2.RuleActionAllow),
+			RuleNumber: aws.Int64(100),
+		},
+	}}
+	target := &NetworkACL{ID: "acl-123", FirstRule: 100, LastRule: 109, Client: client}
+
+	changes, err := target.Plan([]Rule{
+		{Name: "S3", Port: 443, Protocol: ProtocolTCP, Egress: true, CIDRs: []string{"10.0.0.0/24"}},
+	}, Options{})
+	SortChanges(changes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Target: "acl-123", Action: ChangeAdd, Direction: DirectionEgress, Protocol: "all", Value: "0.0.0.0/0", Description: "rule 109 (deny)"},
+		{Target: "acl-123", Action: ChangeAdd, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.0.0/24", Description: "rule 100 (allow)"},
+		{Target: "acl-123", Action: ChangeRemove, Direction: DirectionEgress, Protocol: ProtocolTCP, Port: 443, Value: "10.0.9.0/24", Description: "rule 100 (allow)"},
+	}, changes)
+	assert.Zero(t, client.calls)
+}
+
+func TestWAFIPSetPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		scope  string
+		client *fakeWAFClient
+
+		expectErr     bool
+		expectChanges []Change
+	}{
+		{
+			name:   "Changes",
+			client: newFakeWAFClient(wafv2.IPAddressVersionIpv4, "10.0.1.0/24", "10.0.9.0/24"),
+
+			expectChanges: []Change{
+				{Target: "egress/abc123", Action: ChangeAdd, Value: "10.0.0.0/24"},
+				{Target: "egress/abc123", Action: ChangeRemove, Value: "10.0.9.0/24"},
+			},
+		},
+		{
+			name:   "NotFound",
+			scope:  wafv2.ScopeCloudfront,
+			client: newFakeWAFClient(wafv2.IPAddressVersionIpv4),
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &WAFIPSet{Name: "egress", ID: "abc123", Scope: test.scope, Client: test.client}
+
+			changes, err := target.Plan([]Rule{
+				{Name: "S3", CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+			}, Options{})
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectChanges, changes)
+			assert.Zero(t, test.client.updates)
+		})
+	}
+}
+
+func TestDomainListPlan(t *testing.T) {
+	sleep = func() {}
+
+	client := newFakeResolverClient("www.example.com.", "old.example.com.")
+	target := &DomainList{ID: "rslvr-fdl-123", Client: client}
+
+	changes, err := target.Plan([]Rule{
+		{Name: "api.example.com", SourceType: SourceTypeDNS},
+		{Name: "www.example.com", SourceType: SourceTypeDNS},
+	}, Options{MaxShrinkPercent: 10})
+
+	assert.Error(t, err)
+	assert.Equal(t, []Change{
+		{Target: "rslvr-fdl-123", Action: ChangeAdd, Value: "api.example.com"},
+		{Target: "rslvr-fdl-123", Action: ChangeRemove, Value: "old.example.com"},
+	}, changes)
+	assert.Zero(t, client.updates)
+}
+
+func TestRuleGroupPlan(t *testing.T) {
+	client := newFakeNetworkFirewallClient("api.example.com", "old.example.com")
+	target := &RuleGroup{ARN: "arn:aws:network-firewall:us-west-2:123456789012:stateful-rulegroup/egress", Client: client}
+
+	changes, err := target.Plan([]Rule{
+		{Name: "api.example.com", SourceType: SourceTypeDNS},
+		{Name: ".cdn.example.com", SourceType: SourceTypeDNS},
+	}, Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Target: target.ARN, Action: ChangeAdd, Value: ".cdn.example.com"},
+		{Target: target.ARN, Action: ChangeRemove, Value: "old.example.com"},
+	}, changes)
+	assert.Zero(t, client.updates)
+}
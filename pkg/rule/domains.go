@@ -0,0 +1,75 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Domains returns the names of DNS rules as domains, in lower case without a
+// trailing dot. Rules from other sources, such as AWS services, have no
+// domain and are skipped.
+func Domains(rules []Rule) []string {
+	seen := make(map[string]bool)
+	domains := make([]string, 0)
+
+	for _, rule := range rules {
+		if rule.SourceType != SourceTypeDNS {
+			continue
+		}
+
+		domain := normalizeDomain(rule.Name)
+		if domain == "" || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+
+		domains = append(domains, domain)
+	}
+
+	sort.Strings(domains)
+
+	return domains
+}
+
+// checkDomains returns an error if the desired domains of a domain target are
+// empty and opts do not allow it. Rules without DNS sources, such as AWS
+// services, would otherwise remove every domain from the target.
+func checkDomains(target string, desired []string, opts Options) error {
+	if len(desired) == 0 && !opts.AllowEmptyDomains {
+		return fmt.Errorf("refusing to remove every domain from %s: there are no DNS rules", target)
+	}
+	return nil
+}
+
+// normalizeDomain returns a domain in lower case without a trailing dot.
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(domain), ".")
+}
+
+// planDomains returns the domains to add and remove to reconcile a domain list
+// with the desired domains.
+func planDomains(desired, existing []string) (add, remove []string) {
+	want := make(map[string]bool, len(desired))
+	for _, domain := range desired {
+		want[domain] = true
+	}
+
+	have := make(map[string]bool, len(existing))
+	for _, domain := range existing {
+		domain = normalizeDomain(domain)
+		have[domain] = true
+
+		if !want[domain] {
+			remove = append(remove, domain)
+		}
+	}
+
+	for _, domain := range desired {
+		if !have[domain] {
+			add = append(add, domain)
+		}
+	}
+
+	return add, remove
+}
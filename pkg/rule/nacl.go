@@ -0,0 +1,431 @@
+package rule
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// Network ACL rule number limits.
+const (
+	minACLRuleNumber = 1
+	maxACLRuleNumber = 32766
+)
+
+// aclProtocols maps rule protocols to network ACL protocol numbers.
+var aclProtocols = map[string]string{
+	ProtocolTCP: "6",
+	ProtocolUDP: "17",
+}
+
+// aclAllProtocols is the network ACL protocol which matches all traffic.
+const aclAllProtocols = "-1"
+
+// NetworkACL is a network ACL target. Rules are rendered as numbered allow
+// entries in a reserved range of rule numbers, which is the same for ingress
+// and egress. In each direction which has rules, the last number in the range
+// is a deny entry for all traffic, so traffic in that direction which no rule
+// allows never reaches entries numbered after the range. A direction without
+// rules has no entries in the range. Entries outside the range are never
+// modified.
+//
+// Network ACLs are stateless, so return traffic must be allowed separately,
+// with an entry for ephemeral ports numbered before the range.
+type NetworkACL struct {
+	// ID is the ID of the network ACL.
+	ID string `json:"id"`
+
+	// FirstRule and LastRule are the inclusive range of rule numbers
+	// reserved for rules.
+	FirstRule int64 `json:"firstRule"`
+	LastRule  int64 `json:"lastRule"`
+
+	Client ec2iface.EC2API `json:"-"`
+}
+
+func (t *NetworkACL) String() string {
+	return t.ID
+}
+
+// Validate returns an error if the range of rule numbers is invalid.
+func (t *NetworkACL) Validate() error {
+	if t.FirstRule < minACLRuleNumber || t.LastRule > maxACLRuleNumber || t.FirstRule >= t.LastRule {
+		return fmt.Errorf("network ACL %s: invalid rule number range %d-%d", t.ID, t.FirstRule, t.LastRule)
+	}
+	return nil
+}
+
+// aclEntry is an entry in a network ACL.
+type aclEntry struct {
+	protocol string
+	port     int64
+	cidr     string
+	action   string
+}
+
+// newACLEntry converts an existing network ACL entry.
+func newACLEntry(entry *ec2.NetworkAclEntry) aclEntry {
+	e := aclEntry{
+		protocol: aws.StringValue(entry.Protocol),
+		cidr:     aws.StringValue(entry.CidrBlock),
+		action:   aws.StringValue(entry.RuleAction),
+	}
+	if entry.Ipv6CidrBlock != nil {
+		e.cidr = *entry.Ipv6CidrBlock
+	}
+	// Only single port entries are created, so any other range never
+	// matches a desired entry.
+	if entry.PortRange != nil && aws.Int64Value(entry.PortRange.From) == aws.Int64Value(entry.PortRange.To) {
+		e.port = aws.Int64Value(entry.PortRange.From)
+	} else if entry.PortRange != nil {
+		e.port = -1
+	}
+	return e
+}
+
+// denyAll is the final entry in the reserved range of a direction with rules.
+var denyAll = aclEntry{
+	protocol: aclAllProtocols,
+	cidr:     "0.0.0.0/0",
+	action:   ec2.RuleActionDeny,
+}
+
+// renderACL returns the sorted, unique allow entries for the rules in one
+// direction.
+func renderACL(rules []Rule, egress bool) ([]aclEntry, error) {
+	seen := make(map[aclEntry]bool)
+	entries := make([]aclEntry, 0)
+
+	for _, rule := range rules {
+		if rule.Egress != egress {
+			continue
+		}
+
+		protocol, ok := aclProtocols[rule.Protocol]
+		if !ok {
+			return nil, fmt.Errorf("unsupported network ACL protocol %q for %s", rule.Protocol, rule.Name)
+		}
+
+		for _, cidr := range rule.CIDRs {
+			entry := aclEntry{
+				protocol: protocol,
+				port:     int64(rule.Port),
+				cidr:     cidr,
+				action:   ec2.RuleActionAllow,
+			}
+			if !seen[entry] {
+				seen[entry] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.protocol != b.protocol {
+			return a.protocol < b.protocol
+		}
+		if a.port != b.port {
+			return a.port < b.port
+		}
+		return a.cidr < b.cidr
+	})
+
+	return entries, nil
+}
+
+// aclPlan is the set of changes which reconcile one direction of a network
+// ACL, by rule number.
+type aclPlan struct {
+	create  map[int64]aclEntry
+	replace map[int64]aclEntry
+	delete  []int64
+
+	// existing are the existing entries in the range.
+	existing map[int64]aclEntry
+
+	// owned and removed count the allow entries in the range, and the ones
+	// which are removed.
+	owned   int
+	removed int
+}
+
+// empty returns a boolean for whether the plan makes no changes.
+func (p *aclPlan) empty() bool {
+	return len(p.create) == 0 && len(p.replace) == 0 && len(p.delete) == 0
+}
+
+// plan compares the desired allow entries with the existing entries in the
+// reserved range. Entries which are still desired keep their rule numbers,
+// and new entries take the lowest free rule numbers.
+func (t *NetworkACL) plan(desired []aclEntry, existing map[int64]aclEntry) (*aclPlan, error) {
+	if capacity := t.LastRule - t.FirstRule; int64(len(desired)) > capacity {
+		return nil, fmt.Errorf("network ACL %s: %d entries do not fit in rule numbers %d-%d",
+			t.ID, len(desired), t.FirstRule, t.LastRule-1)
+	}
+
+	p := &aclPlan{
+		create:   make(map[int64]aclEntry),
+		replace:  make(map[int64]aclEntry),
+		existing: existing,
+	}
+
+	wanted := make(map[aclEntry]bool)
+	for _, entry := range desired {
+		wanted[entry] = true
+	}
+
+	kept := make(map[aclEntry]bool)
+	free := make([]int64, 0)
+	for number := t.FirstRule; number < t.LastRule; number++ {
+		entry, ok := existing[number]
+		if ok && entry.action == ec2.RuleActionAllow {
+			p.owned++
+		}
+
+		if ok && wanted[entry] && !kept[entry] {
+			kept[entry] = true
+			continue
+		}
+
+		if ok && entry.action == ec2.RuleActionAllow {
+			p.removed++
+		}
+		free = append(free, number)
+	}
+
+	for _, entry := range desired {
+		if kept[entry] {
+			continue
+		}
+
+		number := free[0]
+		free = free[1:]
+
+		if _, ok := existing[number]; ok {
+			p.replace[number] = entry
+		} else {
+			p.create[number] = entry
+		}
+	}
+
+	for _, number := range free {
+		if _, ok := existing[number]; ok {
+			p.delete = append(p.delete, number)
+		}
+	}
+
+	// Denying all traffic in a direction without rules would also block
+	// return traffic for the other direction.
+	entry, ok := existing[t.LastRule]
+	switch {
+	case len(desired) == 0 && ok:
+		p.delete = append(p.delete, t.LastRule)
+	case len(desired) == 0:
+	case !ok:
+		p.create[t.LastRule] = denyAll
+	case entry != denyAll:
+		p.replace[t.LastRule] = denyAll
+	}
+
+	return p, nil
+}
+
+// Apply renders the rules into the reserved range of rule numbers.
+func (t *NetworkACL) Apply(rules []Rule, opts Options) error {
+	plans, err := t.plans(rules)
+	if err != nil {
+		return err
+	}
+
+	if err := t.checkShrink(plans, opts); err != nil {
+		return err
+	}
+
+	for _, egress := range []bool{true, false} {
+		if err := t.apply(plans[egress], egress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Plan returns the changes which Apply would make to the network ACL.
+// Replaced entries are removed and added with the same rule number.
+func (t *NetworkACL) Plan(rules []Rule, opts Options) ([]Change, error) {
+	plans, err := t.plans(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]Change, 0)
+	for _, egress := range []bool{true, false} {
+		p := plans[egress]
+
+		for number, entry := range p.create {
+			changes = append(changes, aclChange(t.ID, ChangeAdd, number, egress, entry))
+		}
+		for number, entry := range p.replace {
+			changes = append(changes,
+				aclChange(t.ID, ChangeRemove, number, egress, p.existing[number]),
+				aclChange(t.ID, ChangeAdd, number, egress, entry))
+		}
+		for _, number := range p.delete {
+			changes = append(changes, aclChange(t.ID, ChangeRemove, number, egress, p.existing[number]))
+		}
+	}
+
+	return changes, t.checkShrink(plans, opts)
+}
+
+// plans reads the network ACL, and plans its egress and ingress entries.
+func (t *NetworkACL) plans(rules []Rule) (map[bool]*aclPlan, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := t.Client.DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{
+		NetworkAclIds: []*string{aws.String(t.ID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.NetworkAcls) != 1 {
+		return nil, fmt.Errorf("unexpected number of network ACLs: %d", len(res.NetworkAcls))
+	}
+
+	plans := make(map[bool]*aclPlan)
+
+	for _, egress := range []bool{true, false} {
+		desired, err := renderACL(rules, egress)
+		if err != nil {
+			return nil, err
+		}
+
+		existing := make(map[int64]aclEntry)
+		for _, entry := range res.NetworkAcls[0].Entries {
+			number := aws.Int64Value(entry.RuleNumber)
+			if aws.BoolValue(entry.Egress) == egress && number >= t.FirstRule && number <= t.LastRule {
+				existing[number] = newACLEntry(entry)
+			}
+		}
+
+		p, err := t.plan(desired, existing)
+		if err != nil {
+			return nil, err
+		}
+
+		plans[egress] = p
+	}
+
+	return plans, nil
+}
+
+// checkShrink returns an error if the plans remove more than MaxShrinkPercent
+// of the allow entries in the range.
+func (t *NetworkACL) checkShrink(plans map[bool]*aclPlan, opts Options) error {
+	owned, removed := 0, 0
+	for _, p := range plans {
+		owned += p.owned
+		removed += p.removed
+	}
+
+	return checkShrink(removed, owned, t.ID, opts.MaxShrinkPercent)
+}
+
+// apply makes the changes in a plan. Entries are created and replaced before
+// stale entries are deleted.
+func (t *NetworkACL) apply(p *aclPlan, egress bool) error {
+	if p.empty() {
+		log.Printf("Network ACL %s (egress: %t) is already in sync", t.ID, egress)
+		return nil
+	}
+
+	log.Printf("Creating %d, replacing %d and deleting %d entries in network ACL %s (egress: %t)",
+		len(p.create), len(p.replace), len(p.delete), t.ID, egress)
+
+	for _, number := range sortedNumbers(p.create) {
+		entry := p.create[number]
+		cidr, ipv6CIDR := entry.cidrBlocks()
+
+		_, err := t.Client.CreateNetworkAclEntry(&ec2.CreateNetworkAclEntryInput{
+			CidrBlock:     cidr,
+			Egress:        aws.Bool(egress),
+			Ipv6CidrBlock: ipv6CIDR,
+			NetworkAclId:  aws.String(t.ID),
+			PortRange:     entry.portRange(),
+			Protocol:      aws.String(entry.protocol),
+			RuleAction:    aws.String(entry.action),
+			RuleNumber:    aws.Int64(number),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, number := range sortedNumbers(p.replace) {
+		entry := p.replace[number]
+		cidr, ipv6CIDR := entry.cidrBlocks()
+
+		_, err := t.Client.ReplaceNetworkAclEntry(&ec2.ReplaceNetworkAclEntryInput{
+			CidrBlock:     cidr,
+			Egress:        aws.Bool(egress),
+			Ipv6CidrBlock: ipv6CIDR,
+			NetworkAclId:  aws.String(t.ID),
+			PortRange:     entry.portRange(),
+			Protocol:      aws.String(entry.protocol),
+			RuleAction:    aws.String(entry.action),
+			RuleNumber:    aws.Int64(number),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, number := range p.delete {
+		_, err := t.Client.DeleteNetworkAclEntry(&ec2.DeleteNetworkAclEntryInput{
+			NetworkAclId: aws.String(t.ID),
+			Egress:       aws.Bool(egress),
+			RuleNumber:   aws.Int64(number),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// portRange returns the port range of an entry, or nil for all protocols.
+func (e aclEntry) portRange() *ec2.PortRange {
+	if e.protocol == aclAllProtocols {
+		return nil
+	}
+	return &ec2.PortRange{From: aws.Int64(e.port), To: aws.Int64(e.port)}
+}
+
+// cidrBlocks returns the IPv4 or IPv6 CIDR block of an entry.
+func (e aclEntry) cidrBlocks() (*string, *string) {
+	if family(e.cidr) == "IPv6" {
+		return nil, aws.String(e.cidr)
+	}
+	return aws.String(e.cidr), nil
+}
+
+// sortedNumbers returns the rule numbers of a set of entries in order.
+func sortedNumbers(entries map[int64]aclEntry) []int64 {
+	numbers := make([]int64, 0, len(entries))
+	for number := range entries {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool {
+		return numbers[i] < numbers[j]
+	})
+	return numbers
+}
@@ -0,0 +1,289 @@
+package rule
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkACLApply(t *testing.T) {
+	allow := func(number int64, cidr string, port int64) *ec2.NetworkAclEntry {
+		return &ec2.NetworkAclEntry{
+			CidrBlock:  aws.String(cidr),
+			Egress:     aws.Bool(true),
+			PortRange:  &ec2.PortRange{From: aws.Int64(port), To: aws.Int64(port)},
+			Protocol:   aws.String("6"),
+			RuleAction: aws.String(ec2.RuleActionAllow),
+			RuleNumber: aws.Int64(number),
+		}
+	}
+	deny := func(number int64, egress bool) *ec2.NetworkAclEntry {
+		return &ec2.NetworkAclEntry{
+			CidrBlock:  aws.String("0.0.0.0/0"),
+			Egress:     aws.Bool(egress),
+			Protocol:   aws.String("-1"),
+			RuleAction: aws.String(ec2.RuleActionDeny),
+			RuleNumber: aws.Int64(number),
+		}
+	}
+
+	ingress := func(entry *ec2.NetworkAclEntry) *ec2.NetworkAclEntry {
+		entry.Egress = aws.Bool(false)
+		return entry
+	}
+
+	rules := []Rule{
+		{Name: "S3", Port: 443, Protocol: ProtocolTCP, Egress: true, CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+	}
+
+	tests := []struct {
+		name     string
+		rules    []Rule
+		opts     Options
+		existing []*ec2.NetworkAclEntry
+
+		expectErr     bool
+		expectEntries []string
+		expectCalls   int
+	}{
+		{
+			name:  "Create",
+			rules: rules,
+			existing: []*ec2.NetworkAclEntry{
+				allow(50, "192.0.2.0/24", 22),
+				allow(32767, "0.0.0.0/0", 0),
+			},
+
+			expectEntries: []string{
+				"egress 50 allow 6 192.0.2.0/24:22",
+				"egress 100 allow 6 10.0.0.0/24:443",
+				"egress 101 allow 6 10.0.1.0/24:443",
+				"egress 109 deny -1 0.0.0.0/0:0",
+				"egress 32767 allow 6 0.0.0.0/0:0",
+			},
+			expectCalls: 3,
+		},
+		{
+			name:  "EgressOnly",
+			rules: rules,
+			existing: []*ec2.NetworkAclEntry{
+				ingress(allow(50, "0.0.0.0/0", 1024)),
+				ingress(allow(200, "0.0.0.0/0", 0)),
+			},
+
+			expectEntries: []string{
+				"egress 100 allow 6 10.0.0.0/24:443",
+				"egress 101 allow 6 10.0.1.0/24:443",
+				"egress 109 deny -1 0.0.0.0/0:0",
+				"ingress 50 allow 6 0.0.0.0/0:1024",
+				"ingress 200 allow 6 0.0.0.0/0:0",
+			},
+			expectCalls: 3,
+		},
+		{
+			name:  "DirectionBecomesEmpty",
+			rules: rules,
+			existing: []*ec2.NetworkAclEntry{
+				allow(100, "10.0.0.0/24", 443),
+				allow(101, "10.0.1.0/24", 443),
+				deny(109, true),
+				ingress(allow(100, "10.0.5.0/24", 22)),
+				deny(109, false),
+			},
+
+			expectEntries: []string{
+				"egress 100 allow 6 10.0.0.0/24:443",
+				"egress 101 allow 6 10.0.1.0/24:443",
+				"egress 109 deny -1 0.0.0.0/0:0",
+			},
+			expectCalls: 2,
+		},
+		{
+			name:  "KeepNumbers",
+			rules: rules,
+			existing: []*ec2.NetworkAclEntry{
+				allow(100, "10.0.9.0/24", 443),
+				allow(101, "10.0.1.0/24", 443),
+				allow(102, "10.0.8.0/24", 443),
+				deny(109, true),
+			},
+
+			expectEntries: []string{
+				"egress 100 allow 6 10.0.0.0/24:443",
+				"egress 101 allow 6 10.0.1.0/24:443",
+				"egress 109 deny -1 0.0.0.0/0:0",
+			},
+			expectCalls: 2,
+		},
+		{
+			name:  "InSync",
+			rules: rules,
+			existing: []*ec2.NetworkAclEntry{
+				allow(103, "10.0.0.0/24", 443),
+				allow(107, "10.0.1.0/24", 443),
+				deny(109, true),
+			},
+
+			expectEntries: []string{
+				"egress 103 allow 6 10.0.0.0/24:443",
+				"egress 107 allow 6 10.0.1.0/24:443",
+				"egress 109 deny -1 0.0.0.0/0:0",
+			},
+		},
+		{
+			name: "TooManyEntries",
+			rules: []Rule{
+				{Name: "AMAZON", Port: 443, Protocol: ProtocolTCP, Egress: true, CIDRs: []string{
+					"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24", "10.0.4.0/24",
+					"10.0.5.0/24", "10.0.6.0/24", "10.0.7.0/24", "10.0.8.0/24", "10.0.9.0/24",
+				}},
+			},
+
+			expectErr: true,
+		},
+		{
+			name:  "UnsupportedProtocol",
+			rules: []Rule{{Name: "ping", Protocol: ProtoclICMP, Egress: true, CIDRs: []string{"10.0.0.0/24"}}},
+
+			expectErr: true,
+		},
+		{
+			name: "ExceedsMaxShrink",
+			opts: Options{MaxShrinkPercent: 10},
+			existing: []*ec2.NetworkAclEntry{
+				allow(100, "10.0.9.0/24", 443),
+			},
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakeNetworkACLClient{entries: test.existing}
+			target := &NetworkACL{ID: "acl-123", FirstRule: 100, LastRule: 109, Client: client}
+
+			err := target.Apply(test.rules, test.opts)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				assert.Zero(t, client.calls)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectEntries, client.list())
+			assert.Equal(t, test.expectCalls, client.calls)
+		})
+	}
+}
+
+func TestNetworkACLValidate(t *testing.T) {
+	assert.NoError(t, (&NetworkACL{FirstRule: 100, LastRule: 120}).Validate())
+	assert.Error(t, (&NetworkACL{FirstRule: 0, LastRule: 120}).Validate())
+	assert.Error(t, (&NetworkACL{FirstRule: 120, LastRule: 120}).Validate())
+	assert.Error(t, (&NetworkACL{FirstRule: 100, LastRule: 32767}).Validate())
+}
+
+// fakeNetworkACLClient is an in-memory network ACL.
+type fakeNetworkACLClient struct {
+	ec2iface.EC2API
+
+	entries []*ec2.NetworkAclEntry
+
+	// calls is the number of modifications.
+	calls int
+}
+
+// list returns the entries as sorted strings.
+func (c *fakeNetworkACLClient) list() []string {
+	result := make([]string, 0, len(c.entries))
+	for _, direction := range []bool{true, false} {
+		for _, number := range sortedNumbers(c.numbers(direction)) {
+			entry := c.find(direction, number)
+			name := "ingress"
+			if direction {
+				name = "egress"
+			}
+			port := int64(0)
+			if entry.PortRange != nil {
+				port = *entry.PortRange.From
+			}
+			result = append(result, fmt.Sprintf("%s %d %s %s %s:%d",
+				name, number, *entry.RuleAction, *entry.Protocol, *entry.CidrBlock, port))
+		}
+	}
+	return result
+}
+
+func (c *fakeNetworkACLClient) numbers(egress bool) map[int64]aclEntry {
+	numbers := make(map[int64]aclEntry)
+	for _, entry := range c.entries {
+		if *entry.Egress == egress {
+			numbers[*entry.RuleNumber] = aclEntry{}
+		}
+	}
+	return numbers
+}
+
+func (c *fakeNetworkACLClient) find(egress bool, number int64) *ec2.NetworkAclEntry {
+	for _, entry := range c.entries {
+		if *entry.Egress == egress && *entry.RuleNumber == number {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (c *fakeNetworkACLClient) DescribeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	return &ec2.DescribeNetworkAclsOutput{
+		NetworkAcls: []*ec2.NetworkAcl{
+			{NetworkAclId: input.NetworkAclIds[0], Entries: c.entries},
+		},
+	}, nil
+}
+
+func (c *fakeNetworkACLClient) CreateNetworkAclEntry(input *ec2.CreateNetworkAclEntryInput) (*ec2.CreateNetworkAclEntryOutput, error) {
+	if c.find(*input.Egress, *input.RuleNumber) != nil {
+		return nil, fmt.Errorf("entry %d already exists", *input.RuleNumber)
+	}
+
+	c.calls++
+	c.entries = append(c.entries, &ec2.NetworkAclEntry{
+		CidrBlock:  input.CidrBlock,
+		Egress:     input.Egress,
+		PortRange:  input.PortRange,
+		Protocol:   input.Protocol,
+		RuleAction: input.RuleAction,
+		RuleNumber: input.RuleNumber,
+	})
+	return &ec2.CreateNetworkAclEntryOutput{}, nil
+}
+
+func (c *fakeNetworkACLClient) ReplaceNetworkAclEntry(input *ec2.ReplaceNetworkAclEntryInput) (*ec2.ReplaceNetworkAclEntryOutput, error) {
+	entry := c.find(*input.Egress, *input.RuleNumber)
+	if entry == nil {
+		return nil, fmt.Errorf("entry %d does not exist", *input.RuleNumber)
+	}
+
+	c.calls++
+	entry.CidrBlock = input.CidrBlock
+	entry.PortRange = input.PortRange
+	entry.Protocol = input.Protocol
+	entry.RuleAction = input.RuleAction
+	return &ec2.ReplaceNetworkAclEntryOutput{}, nil
+}
+
+func (c *fakeNetworkACLClient) DeleteNetworkAclEntry(input *ec2.DeleteNetworkAclEntryInput) (*ec2.DeleteNetworkAclEntryOutput, error) {
+	for i, entry := range c.entries {
+		if *entry.Egress == *input.Egress && *entry.RuleNumber == *input.RuleNumber {
+			c.calls++
+			c.entries = append(c.entries[:i], c.entries[i+1:]...)
+			return &ec2.DeleteNetworkAclEntryOutput{}, nil
+		}
+	}
+	return nil, fmt.Errorf("entry %d does not exist", *input.RuleNumber)
+}
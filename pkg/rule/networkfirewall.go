@@ -0,0 +1,121 @@
+package rule
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/networkfirewall"
+	"github.com/aws/aws-sdk-go/service/networkfirewall/networkfirewalliface"
+)
+
+// ruleGroupAttempts is the number of times a rule group is read and updated
+// before giving up on concurrent updates.
+const ruleGroupAttempts = 5
+
+// RuleGroup is an AWS Network Firewall stateful domain list rule group target.
+// The names of DNS rules are kept in the rule group's targets, so the firewall
+// can allow or block them by TLS SNI or HTTP host. The rule group's
+// generated rules type and target types are left unchanged. The target owns
+// every domain in the rule group and the owner is ignored.
+type RuleGroup struct {
+	ARN    string
+	Client networkfirewalliface.NetworkFirewallAPI
+}
+
+func (t *RuleGroup) String() string {
+	return t.ARN
+}
+
+// Apply updates the domains in the rule group. Every update is made with the
+// update token of the rule group which was read, and the rule group is read
+// again if another writer updates it first.
+func (t *RuleGroup) Apply(rules []Rule, opts Options) error {
+	for attempt := 0; attempt < ruleGroupAttempts; attempt++ {
+		err := t.reconcile(rules, opts)
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == networkfirewall.ErrCodeInvalidTokenException {
+			log.Printf("Rule group %s was updated concurrently: %v", t.ARN, err)
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("rule group %s was updated concurrently %d times", t.ARN, ruleGroupAttempts)
+}
+
+// Plan returns the changes which Apply would make to the rule group.
+func (t *RuleGroup) Plan(rules []Rule, opts Options) ([]Change, error) {
+	desired := Domains(rules)
+	if err := checkDomains(t.ARN, desired, opts); err != nil {
+		return nil, err
+	}
+
+	res, err := t.describe()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := res.RuleGroup.RulesSource.RulesSourceList.Targets
+	add, remove := planDomains(desired, aws.StringValueSlice(existing))
+
+	return domainChanges(t.ARN, add, remove), checkShrink(len(remove), len(existing), t.ARN, opts.MaxShrinkPercent)
+}
+
+// describe describes the rule group, and returns an error if it is not a
+// domain list rule group.
+func (t *RuleGroup) describe() (*networkfirewall.DescribeRuleGroupOutput, error) {
+	res, err := t.Client.DescribeRuleGroup(&networkfirewall.DescribeRuleGroupInput{
+		RuleGroupArn: aws.String(t.ARN),
+		Type:         aws.String(networkfirewall.RuleGroupTypeStateful),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.RuleGroup == nil || res.RuleGroup.RulesSource == nil || res.RuleGroup.RulesSource.RulesSourceList == nil {
+		return nil, fmt.Errorf("rule group %s is not a domain list rule group", t.ARN)
+	}
+
+	return res, nil
+}
+
+// reconcile reads the rule group and applies one plan to it.
+func (t *RuleGroup) reconcile(rules []Rule, opts Options) error {
+	desired := Domains(rules)
+	if err := checkDomains(t.ARN, desired, opts); err != nil {
+		return err
+	}
+
+	res, err := t.describe()
+	if err != nil {
+		return err
+	}
+	list := res.RuleGroup.RulesSource.RulesSourceList
+
+	existing := aws.StringValueSlice(list.Targets)
+
+	add, remove := planDomains(desired, existing)
+
+	if len(add) == 0 && len(remove) == 0 {
+		log.Printf("Rule group %s is already in sync", t.ARN)
+		return nil
+	}
+
+	if err := checkShrink(len(remove), len(existing), t.ARN, opts.MaxShrinkPercent); err != nil {
+		return err
+	}
+
+	log.Printf("Removing %d and adding %d domains in rule group %s", len(remove), len(add), t.ARN)
+
+	list.Targets = aws.StringSlice(desired)
+
+	_, err = t.Client.UpdateRuleGroup(&networkfirewall.UpdateRuleGroupInput{
+		Description:             res.RuleGroupResponse.Description,
+		EncryptionConfiguration: res.RuleGroupResponse.EncryptionConfiguration,
+		RuleGroup:               res.RuleGroup,
+		RuleGroupArn:            aws.String(t.ARN),
+		UpdateToken:             res.UpdateToken,
+	})
+	return err
+}
@@ -0,0 +1,181 @@
+package rule
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/networkfirewall"
+	"github.com/aws/aws-sdk-go/service/networkfirewall/networkfirewalliface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleGroupApply(t *testing.T) {
+	rules := []Rule{
+		{Name: "api.example.com", SourceType: SourceTypeDNS},
+		{Name: ".cdn.example.com", SourceType: SourceTypeDNS},
+		{Name: "S3", SourceType: "awsService", CIDRs: []string{"10.0.0.0/24"}},
+	}
+
+	tests := []struct {
+		name   string
+		opts   Options
+		client *fakeNetworkFirewallClient
+
+		expectErr     bool
+		expectTargets []string
+		expectUpdates int
+	}{
+		{
+			name:   "AddAndRemove",
+			client: newFakeNetworkFirewallClient("api.example.com", "old.example.com"),
+
+			expectTargets: []string{".cdn.example.com", "api.example.com"},
+			expectUpdates: 1,
+		},
+		{
+			name:   "InSync",
+			client: newFakeNetworkFirewallClient("api.example.com", ".cdn.example.com"),
+
+			expectTargets: []string{"api.example.com", ".cdn.example.com"},
+		},
+		{
+			name: "ConcurrentUpdate",
+			client: func() *fakeNetworkFirewallClient {
+				c := newFakeNetworkFirewallClient()
+				c.conflicts = 2
+				return c
+			}(),
+
+			expectTargets: []string{".cdn.example.com", "api.example.com"},
+			expectUpdates: 1,
+		},
+		{
+			name: "PersistentConcurrentUpdate",
+			client: func() *fakeNetworkFirewallClient {
+				c := newFakeNetworkFirewallClient()
+				c.conflicts = ruleGroupAttempts
+				return c
+			}(),
+
+			expectErr: true,
+		},
+		{
+			name: "NotDomainList",
+			client: func() *fakeNetworkFirewallClient {
+				c := newFakeNetworkFirewallClient()
+				c.rulesSource = &networkfirewall.RulesSource{RulesString: aws.String("pass tcp any any -> any 443 (sid:1;)")}
+				return c
+			}(),
+
+			expectErr: true,
+		},
+		{
+			name:   "ExceedsMaxShrink",
+			opts:   Options{MaxShrinkPercent: 10},
+			client: newFakeNetworkFirewallClient("api.example.com", "old.example.com"),
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &RuleGroup{ARN: "arn:aws:network-firewall:us-west-2:123456789012:stateful-rulegroup/egress", Client: test.client}
+
+			err := target.Apply(rules, test.opts)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			list := test.client.rulesSource.RulesSourceList
+			assert.Equal(t, test.expectTargets, aws.StringValueSlice(list.Targets))
+			assert.Equal(t, networkfirewall.GeneratedRulesTypeAllowlist, aws.StringValue(list.GeneratedRulesType))
+			assert.Equal(t, test.expectUpdates, test.client.updates)
+		})
+	}
+}
+
+func TestRuleGroupNonDNSRules(t *testing.T) {
+	rules := []Rule{{Name: "S3", SourceType: "awsService", CIDRs: []string{"10.0.0.0/24"}}}
+
+	client := newFakeNetworkFirewallClient("api.example.com", "www.example.com")
+	target := &RuleGroup{ARN: "arn:aws:network-firewall:us-west-2:123456789012:stateful-rulegroup/egress", Client: client}
+
+	_, err := target.Plan(rules, Options{})
+	assert.Error(t, err)
+	assert.Error(t, target.Apply(rules, Options{}))
+	assert.Equal(t, []string{"api.example.com", "www.example.com"}, aws.StringValueSlice(client.rulesSource.RulesSourceList.Targets))
+	assert.Zero(t, client.updates)
+
+	assert.NoError(t, target.Apply(rules, Options{AllowEmptyDomains: true}))
+	assert.Empty(t, client.rulesSource.RulesSourceList.Targets)
+}
+
+// fakeNetworkFirewallClient is an in-memory stateful rule group.
+type fakeNetworkFirewallClient struct {
+	networkfirewalliface.NetworkFirewallAPI
+
+	token       int
+	rulesSource *networkfirewall.RulesSource
+
+	// conflicts is the number of updates to fail with a stale update token,
+	// as if another writer updated the rule group.
+	conflicts int
+
+	// updates is the number of successful updates.
+	updates int
+}
+
+func newFakeNetworkFirewallClient(targets ...string) *fakeNetworkFirewallClient {
+	return &fakeNetworkFirewallClient{
+		rulesSource: &networkfirewall.RulesSource{
+			RulesSourceList: &networkfirewall.RulesSourceList{
+				GeneratedRulesType: aws.String(networkfirewall.GeneratedRulesTypeAllowlist),
+				TargetTypes:        aws.StringSlice([]string{networkfirewall.TargetTypeTlsSni}),
+				Targets:            aws.StringSlice(targets),
+			},
+		},
+	}
+}
+
+func (c *fakeNetworkFirewallClient) DescribeRuleGroup(input *networkfirewall.DescribeRuleGroupInput) (*networkfirewall.DescribeRuleGroupOutput, error) {
+	// Every response is a copy, so callers may modify it.
+	rulesSource := *c.rulesSource
+	if c.rulesSource.RulesSourceList != nil {
+		list := *c.rulesSource.RulesSourceList
+		rulesSource.RulesSourceList = &list
+	}
+
+	return &networkfirewall.DescribeRuleGroupOutput{
+		RuleGroup: &networkfirewall.RuleGroup{RulesSource: &rulesSource},
+		RuleGroupResponse: &networkfirewall.RuleGroupResponse{
+			RuleGroupArn: input.RuleGroupArn,
+			Description:  aws.String("managed by dsg"),
+		},
+		UpdateToken: aws.String(strconv.Itoa(c.token)),
+	}, nil
+}
+
+func (c *fakeNetworkFirewallClient) UpdateRuleGroup(input *networkfirewall.UpdateRuleGroupInput) (*networkfirewall.UpdateRuleGroupOutput, error) {
+	if c.conflicts > 0 {
+		c.conflicts--
+		c.token++
+	}
+
+	if aws.StringValue(input.UpdateToken) != strconv.Itoa(c.token) {
+		return nil, awserr.New(networkfirewall.ErrCodeInvalidTokenException, "The update token is stale", nil)
+	}
+
+	c.token++
+	c.rulesSource = input.RuleGroup.RulesSource
+	c.updates++
+
+	return &networkfirewall.UpdateRuleGroupOutput{
+		UpdateToken: aws.String(strconv.Itoa(c.token)),
+	}, nil
+}
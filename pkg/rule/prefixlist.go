@@ -0,0 +1,298 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// Managed prefix list limits.
+const (
+	// prefixListBatchSize is the largest number of entries which may be
+	// added, or removed, in one modification.
+	prefixListBatchSize = 100
+
+	// prefixListAttempts is the number of times a prefix list is read and
+	// modified before giving up on concurrent modifications.
+	prefixListAttempts = 5
+
+	// prefixListPolls is the number of times a prefix list is polled while
+	// a modification is in progress.
+	prefixListPolls = 60
+)
+
+// prefixListConflictCodes are the error codes returned when a prefix list
+// was modified since it was read.
+var prefixListConflictCodes = map[string]bool{
+	"PrefixListVersionMismatch": true,
+	"IncorrectState":            true,
+}
+
+// sleep waits between polls, and is replaced in tests.
+var sleep = func() { time.Sleep(2 * time.Second) }
+
+// errResized is returned by reconcile after the prefix list is resized, so
+// that it is read again.
+var errResized = errors.New("prefix list resized")
+
+// PrefixList is a customer-managed prefix list target. Like security group
+// rules, entries belong to the owner in their description, and entries of
+// other owners are never removed. Ports, protocols and directions do not
+// apply to prefix lists, and are configured by the rules which reference
+// them.
+type PrefixList struct {
+	ID     string
+	Client ec2iface.EC2API
+}
+
+func (t *PrefixList) String() string {
+	return t.ID
+}
+
+// Apply adds and removes entries in the prefix list. Every modification is
+// made against the version of the prefix list which was read, and the prefix
+// list is read again if it is modified concurrently. The maximum number of
+// entries is increased if needed.
+func (t *PrefixList) Apply(rules []Rule, opts Options) error {
+	for attempt := 0; attempt < prefixListAttempts; attempt++ {
+		err := t.reconcile(rules, opts)
+		if err == errResized {
+			continue
+		}
+		if aerr, ok := err.(awserr.Error); ok && prefixListConflictCodes[aerr.Code()] {
+			log.Printf("Prefix list %s was modified concurrently: %v", t.ID, err)
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("prefix list %s was modified concurrently %d times", t.ID, prefixListAttempts)
+}
+
+// Plan returns the changes which Apply would make to the prefix list.
+func (t *PrefixList) Plan(rules []Rule, opts Options) ([]Change, error) {
+	_, _, p, err := t.plan(rules, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return entryChanges(t.ID, p), p.CheckShrink(t.ID, opts.MaxShrinkPercent)
+}
+
+// plan reads the prefix list and its entries, and plans the entries of its
+// address family.
+func (t *PrefixList) plan(rules []Rule, opts Options) (*ec2.ManagedPrefixList, []Entry, *Plan, error) {
+	pl, err := t.wait()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	existing, err := t.entries(pl.Version)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	desired := make([]Entry, 0)
+	for _, entry := range Entries(rules, opts.Owner) {
+		if family(entry.CIDR) == aws.StringValue(pl.AddressFamily) {
+			desired = append(desired, entry)
+		}
+	}
+
+	p := PlanEntries(desired, existing, func(entry Entry) bool {
+		return Owned(entry.Description, opts.Owner)
+	})
+
+	return pl, existing, p, nil
+}
+
+// reconcile reads the prefix list and applies one plan to it.
+func (t *PrefixList) reconcile(rules []Rule, opts Options) error {
+	pl, existing, p, err := t.plan(rules, opts)
+	if err != nil {
+		return err
+	}
+
+	if p.Empty() {
+		log.Printf("Prefix list %s is already in sync", t.ID)
+		return nil
+	}
+
+	if err := p.CheckShrink(t.ID, opts.MaxShrinkPercent); err != nil {
+		return err
+	}
+
+	// Modifications never grow the prefix list beyond its final size.
+	size := int64(len(existing) + len(p.Add) - len(p.Remove))
+	if size > aws.Int64Value(pl.MaxEntries) {
+		log.Printf("Resizing prefix list %s from %d to %d entries", t.ID, aws.Int64Value(pl.MaxEntries), size)
+
+		if err := t.modify(&ec2.ModifyManagedPrefixListInput{
+			PrefixListId:   aws.String(t.ID),
+			CurrentVersion: pl.Version,
+			MaxEntries:     aws.Int64(size),
+		}); err != nil {
+			return err
+		}
+
+		return errResized
+	}
+
+	version := pl.Version
+	for _, batch := range batches(p) {
+		log.Printf("Removing %d and adding %d entries in prefix list %s version %d",
+			len(batch.RemoveEntries), len(batch.AddEntries), t.ID, aws.Int64Value(version))
+
+		batch.PrefixListId = aws.String(t.ID)
+		batch.CurrentVersion = version
+		if err := t.modify(batch); err != nil {
+			return err
+		}
+
+		current, err := t.wait()
+		if err != nil {
+			return err
+		}
+		version = current.Version
+	}
+
+	return nil
+}
+
+// batches splits a plan into modifications within the prefix list API's
+// limits. Each modification removes as many entries as it adds, or more, until
+// every entry is removed, so the prefix list never holds more than the larger
+// of its current and final size.
+func batches(p *Plan) []*ec2.ModifyManagedPrefixListInput {
+	result := make([]*ec2.ModifyManagedPrefixListInput, 0)
+
+	for i := 0; i < len(p.Remove) || i < len(p.Add); i += prefixListBatchSize {
+		batch := &ec2.ModifyManagedPrefixListInput{}
+
+		if i < len(p.Remove) {
+			for _, entry := range p.Remove[i:minInt(i+prefixListBatchSize, len(p.Remove))] {
+				batch.RemoveEntries = append(batch.RemoveEntries, &ec2.RemovePrefixListEntry{
+					Cidr: aws.String(entry.CIDR),
+				})
+			}
+		}
+
+		if i < len(p.Add) {
+			for _, entry := range p.Add[i:minInt(i+prefixListBatchSize, len(p.Add))] {
+				batch.AddEntries = append(batch.AddEntries, &ec2.AddPrefixListEntry{
+					Cidr:        aws.String(entry.CIDR),
+					Description: entry.Description,
+				})
+			}
+		}
+
+		result = append(result, batch)
+	}
+
+	return result
+}
+
+// minInt returns the smaller of two integers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// modify modifies the prefix list and waits for the modification to finish.
+func (t *PrefixList) modify(input *ec2.ModifyManagedPrefixListInput) error {
+	if _, err := t.Client.ModifyManagedPrefixList(input); err != nil {
+		return err
+	}
+
+	pl, err := t.wait()
+	if err != nil {
+		return err
+	}
+
+	if aws.StringValue(pl.State) == ec2.PrefixListStateModifyFailed {
+		return fmt.Errorf("failed to modify prefix list %s: %s", t.ID, aws.StringValue(pl.StateMessage))
+	}
+
+	return nil
+}
+
+// describe describes the prefix list.
+func (t *PrefixList) describe() (*ec2.ManagedPrefixList, error) {
+	res, err := t.Client.DescribeManagedPrefixLists(&ec2.DescribeManagedPrefixListsInput{
+		PrefixListIds: []*string{aws.String(t.ID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.PrefixLists) != 1 {
+		return nil, fmt.Errorf("unexpected number of prefix lists: %d", len(res.PrefixLists))
+	}
+
+	return res.PrefixLists[0], nil
+}
+
+// wait describes the prefix list once no operation is in progress.
+func (t *PrefixList) wait() (*ec2.ManagedPrefixList, error) {
+	for i := 0; i < prefixListPolls; i++ {
+		pl, err := t.describe()
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.HasSuffix(aws.StringValue(pl.State), "-in-progress") {
+			return pl, nil
+		}
+
+		sleep()
+	}
+
+	return nil, fmt.Errorf("timed out waiting for prefix list %s", t.ID)
+}
+
+// entries returns the entries in a version of the prefix list.
+func (t *PrefixList) entries(version *int64) ([]Entry, error) {
+	input := &ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId:  aws.String(t.ID),
+		TargetVersion: version,
+	}
+
+	entries := make([]Entry, 0)
+	for {
+		res, err := t.Client.GetManagedPrefixListEntries(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range res.Entries {
+			entries = append(entries, Entry{
+				CIDR:        aws.StringValue(entry.Cidr),
+				Description: entry.Description,
+			})
+		}
+
+		if aws.StringValue(res.NextToken) == "" {
+			return entries, nil
+		}
+		input.NextToken = res.NextToken
+	}
+}
+
+// family returns the address family of a CIDR, as used by prefix lists.
+func family(cidr string) string {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return "IPv6"
+	}
+	return "IPv4"
+}
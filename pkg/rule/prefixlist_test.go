@@ -0,0 +1,267 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanEntries(t *testing.T) {
+	rules := []Rule{
+		{Name: "S3", Port: 443, CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+		{Name: "S3", Port: 80, CIDRs: []string{"10.0.1.0/24"}},
+	}
+
+	existing := []Entry{
+		{CIDR: "10.0.1.0/24", Description: aws.String("AUTOGENERATED: S3")},
+		{CIDR: "10.0.2.0/24", Description: aws.String("AUTOGENERATED: S3")},
+		{CIDR: "10.0.3.0/24", Description: aws.String("office")},
+	}
+
+	p := PlanEntries(Entries(rules, ""), existing, func(entry Entry) bool {
+		return Owned(entry.Description, "")
+	})
+
+	assert.Equal(t, []Entry{{CIDR: "10.0.0.0/24", Description: aws.String("AUTOGENERATED: S3")}}, p.Add)
+	assert.Equal(t, []Entry{{CIDR: "10.0.2.0/24", Description: aws.String("AUTOGENERATED: S3")}}, p.Remove)
+	assert.Equal(t, 2, p.Owned)
+	assert.False(t, p.Empty())
+	assert.Error(t, p.CheckShrink("pl-123", 25))
+	assert.NoError(t, p.CheckShrink("pl-123", 50))
+}
+
+func TestPrefixListApply(t *testing.T) {
+	sleep = func() {}
+
+	cidrs := func(prefix string, n int) []string {
+		result := make([]string, n)
+		for i := range result {
+			result[i] = fmt.Sprintf("%s.%d.0/24", prefix, i)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		rules  []Rule
+		opts   Options
+		client *fakePrefixListClient
+
+		expectErr        bool
+		expectEntries    map[string]string
+		expectMaxEntries int64
+		expectModifies   int
+	}{
+		{
+			name: "AddAndRemove",
+			rules: []Rule{
+				{Name: "S3", CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24", "2001:db8::/32"}},
+			},
+			client: newFakePrefixListClient(10, map[string]string{
+				"10.0.1.0/24": "AUTOGENERATED: S3",
+				"10.0.2.0/24": "AUTOGENERATED: S3",
+				"10.0.3.0/24": "AUTOGENERATED[other]: S3",
+				"10.0.4.0/24": "office",
+			}),
+
+			expectEntries: map[string]string{
+				"10.0.0.0/24": "AUTOGENERATED: S3",
+				"10.0.1.0/24": "AUTOGENERATED: S3",
+				"10.0.3.0/24": "AUTOGENERATED[other]: S3",
+				"10.0.4.0/24": "office",
+			},
+			expectMaxEntries: 10,
+			expectModifies:   1,
+		},
+		{
+			name: "InSync",
+			rules: []Rule{
+				{Name: "S3", CIDRs: []string{"10.0.0.0/24"}},
+			},
+			client: newFakePrefixListClient(10, map[string]string{
+				"10.0.0.0/24": "AUTOGENERATED: S3",
+			}),
+
+			expectEntries: map[string]string{
+				"10.0.0.0/24": "AUTOGENERATED: S3",
+			},
+			expectMaxEntries: 10,
+		},
+		{
+			name: "ResizeAndBatch",
+			rules: []Rule{
+				{Name: "AMAZON", CIDRs: cidrs("10.1", 150)},
+			},
+			client: newFakePrefixListClient(10, map[string]string{
+				"10.0.0.0/24": "AUTOGENERATED: AMAZON",
+			}),
+
+			expectMaxEntries: 150,
+			expectModifies:   2,
+		},
+		{
+			name: "ConcurrentModification",
+			rules: []Rule{
+				{Name: "S3", CIDRs: []string{"10.0.0.0/24"}},
+			},
+			client: func() *fakePrefixListClient {
+				c := newFakePrefixListClient(10, map[string]string{})
+				c.conflicts = 1
+				return c
+			}(),
+
+			expectEntries: map[string]string{
+				"10.0.0.0/24": "AUTOGENERATED: S3",
+			},
+			expectMaxEntries: 10,
+			expectModifies:   1,
+		},
+		{
+			name: "PersistentConflict",
+			rules: []Rule{
+				{Name: "S3", CIDRs: []string{"10.0.0.0/24"}},
+			},
+			client: func() *fakePrefixListClient {
+				c := newFakePrefixListClient(10, map[string]string{})
+				c.conflicts = prefixListAttempts
+				return c
+			}(),
+
+			expectErr: true,
+		},
+		{
+			name: "ExceedsMaxShrink",
+			opts: Options{MaxShrinkPercent: 10},
+			client: newFakePrefixListClient(10, map[string]string{
+				"10.0.0.0/24": "AUTOGENERATED: S3",
+			}),
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &PrefixList{ID: "pl-123", Client: test.client}
+
+			err := target.Apply(test.rules, test.opts)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			if test.expectEntries != nil {
+				assert.Equal(t, test.expectEntries, test.client.entries)
+			} else {
+				assert.Len(t, test.client.entries, 150)
+			}
+			assert.Equal(t, test.expectMaxEntries, test.client.maxEntries)
+			assert.Equal(t, test.expectModifies, test.client.modifies)
+		})
+	}
+}
+
+// fakePrefixListClient is an in-memory IPv4 prefix list.
+type fakePrefixListClient struct {
+	ec2iface.EC2API
+
+	version    int64
+	maxEntries int64
+	entries    map[string]string
+
+	// conflicts is the number of entry modifications to fail with a
+	// version mismatch, as if another writer modified the list.
+	conflicts int
+
+	// modifies is the number of successful entry modifications.
+	modifies int
+}
+
+func newFakePrefixListClient(maxEntries int64, entries map[string]string) *fakePrefixListClient {
+	return &fakePrefixListClient{
+		version:    1,
+		maxEntries: maxEntries,
+		entries:    entries,
+	}
+}
+
+func (c *fakePrefixListClient) DescribeManagedPrefixLists(input *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error) {
+	return &ec2.DescribeManagedPrefixListsOutput{
+		PrefixLists: []*ec2.ManagedPrefixList{
+			{
+				AddressFamily: aws.String("IPv4"),
+				MaxEntries:    aws.Int64(c.maxEntries),
+				PrefixListId:  input.PrefixListIds[0],
+				State:         aws.String(ec2.PrefixListStateModifyComplete),
+				Version:       aws.Int64(c.version),
+			},
+		},
+	}, nil
+}
+
+func (c *fakePrefixListClient) GetManagedPrefixListEntries(input *ec2.GetManagedPrefixListEntriesInput) (*ec2.GetManagedPrefixListEntriesOutput, error) {
+	if aws.Int64Value(input.TargetVersion) != c.version {
+		return nil, fmt.Errorf("unexpected version %d", aws.Int64Value(input.TargetVersion))
+	}
+
+	keys := make([]string, 0, len(c.entries))
+	for cidr := range c.entries {
+		keys = append(keys, cidr)
+	}
+	sort.Strings(keys)
+
+	out := &ec2.GetManagedPrefixListEntriesOutput{}
+	for _, cidr := range keys {
+		out.Entries = append(out.Entries, &ec2.PrefixListEntry{
+			Cidr:        aws.String(cidr),
+			Description: aws.String(c.entries[cidr]),
+		})
+	}
+	return out, nil
+}
+
+func (c *fakePrefixListClient) ModifyManagedPrefixList(input *ec2.ModifyManagedPrefixListInput) (*ec2.ModifyManagedPrefixListOutput, error) {
+	if input.CurrentVersion != nil && *input.CurrentVersion != c.version {
+		return nil, awserr.New("PrefixListVersionMismatch", "The prefix list has been modified", nil)
+	}
+
+	if input.MaxEntries != nil {
+		if len(input.AddEntries) > 0 || len(input.RemoveEntries) > 0 {
+			return nil, awserr.New("InvalidParameterCombination", "MaxEntries cannot be modified with entries", nil)
+		}
+		c.maxEntries = *input.MaxEntries
+		return &ec2.ModifyManagedPrefixListOutput{}, nil
+	}
+
+	if c.conflicts > 0 {
+		c.conflicts--
+		c.version++
+		return nil, awserr.New("PrefixListVersionMismatch", "The prefix list has been modified", nil)
+	}
+
+	if len(input.AddEntries) > prefixListBatchSize || len(input.RemoveEntries) > prefixListBatchSize {
+		return nil, awserr.New("InvalidParameterValue", "too many entries", nil)
+	}
+
+	for _, entry := range input.RemoveEntries {
+		delete(c.entries, *entry.Cidr)
+	}
+	for _, entry := range input.AddEntries {
+		c.entries[*entry.Cidr] = aws.StringValue(entry.Description)
+	}
+	if int64(len(c.entries)) > c.maxEntries {
+		return nil, awserr.New("PrefixListMaxEntriesExceeded", "too many entries", nil)
+	}
+
+	c.version++
+	c.modifies++
+	return &ec2.ModifyManagedPrefixListOutput{}, nil
+}
@@ -0,0 +1,157 @@
+package rule
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/route53resolver/route53resolveriface"
+)
+
+// DNS Firewall domain list limits.
+const (
+	// domainListBatchSize is the largest number of domains which may be
+	// added, or removed, in one update.
+	domainListBatchSize = 1000
+
+	// domainListPolls is the number of times a domain list is polled while
+	// an update is in progress.
+	domainListPolls = 60
+)
+
+// DomainList is a Route 53 Resolver DNS Firewall domain list target. The
+// names of DNS rules are kept in the domain list, so firewall rule groups can
+// allow or block them at the DNS layer. Domain lists have no per-domain
+// descriptions, so the target owns every domain in the domain list and the
+// owner is ignored.
+type DomainList struct {
+	ID     string
+	Client route53resolveriface.Route53ResolverAPI
+}
+
+func (t *DomainList) String() string {
+	return t.ID
+}
+
+// Apply updates the domains in the domain list.
+func (t *DomainList) Apply(rules []Rule, opts Options) error {
+	add, remove, existing, err := t.plan(rules, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(add) == 0 && len(remove) == 0 {
+		log.Printf("Domain list %s is already in sync", t.ID)
+		return nil
+	}
+
+	if err := checkShrink(len(remove), existing, t.ID, opts.MaxShrinkPercent); err != nil {
+		return err
+	}
+
+	log.Printf("Removing %d and adding %d domains in domain list %s", len(remove), len(add), t.ID)
+
+	if err := t.update(route53resolver.FirewallDomainUpdateOperationRemove, remove); err != nil {
+		return err
+	}
+
+	return t.update(route53resolver.FirewallDomainUpdateOperationAdd, add)
+}
+
+// Plan returns the changes which Apply would make to the domain list.
+func (t *DomainList) Plan(rules []Rule, opts Options) ([]Change, error) {
+	add, remove, existing, err := t.plan(rules, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return domainChanges(t.ID, add, remove), checkShrink(len(remove), existing, t.ID, opts.MaxShrinkPercent)
+}
+
+// plan waits for the domain list, and returns the domains to add and remove,
+// and the number of existing domains. It is an error if there are no desired
+// domains and opts do not allow the domain list to be emptied.
+func (t *DomainList) plan(rules []Rule, opts Options) (add, remove []string, existing int, err error) {
+	desired := Domains(rules)
+	if err := checkDomains(t.ID, desired, opts); err != nil {
+		return nil, nil, 0, err
+	}
+
+	if err := t.wait(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	domains, err := t.domains()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	add, remove = planDomains(desired, domains)
+	return add, remove, len(domains), nil
+}
+
+// update adds or removes domains in batches, and waits for each update to
+// finish.
+func (t *DomainList) update(operation string, domains []string) error {
+	for i := 0; i < len(domains); i += domainListBatchSize {
+		if _, err := t.Client.UpdateFirewallDomains(&route53resolver.UpdateFirewallDomainsInput{
+			Domains:              aws.StringSlice(domains[i:minInt(i+domainListBatchSize, len(domains))]),
+			FirewallDomainListId: aws.String(t.ID),
+			Operation:            aws.String(operation),
+		}); err != nil {
+			return err
+		}
+
+		if err := t.wait(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wait returns once no update or import of the domain list is in progress.
+func (t *DomainList) wait() error {
+	for i := 0; i < domainListPolls; i++ {
+		res, err := t.Client.GetFirewallDomainList(&route53resolver.GetFirewallDomainListInput{
+			FirewallDomainListId: aws.String(t.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		switch status := aws.StringValue(res.FirewallDomainList.Status); status {
+		case route53resolver.FirewallDomainListStatusUpdating, route53resolver.FirewallDomainListStatusImporting:
+			sleep()
+		case route53resolver.FirewallDomainListStatusComplete, route53resolver.FirewallDomainListStatusCompleteImportFailed:
+			return nil
+		default:
+			return fmt.Errorf("domain list %s is %s: %s", t.ID, status, aws.StringValue(res.FirewallDomainList.StatusMessage))
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for domain list %s", t.ID)
+}
+
+// domains returns every domain in the domain list.
+func (t *DomainList) domains() ([]string, error) {
+	input := &route53resolver.ListFirewallDomainsInput{
+		FirewallDomainListId: aws.String(t.ID),
+	}
+
+	domains := make([]string, 0)
+	for {
+		res, err := t.Client.ListFirewallDomains(input)
+		if err != nil {
+			return nil, err
+		}
+
+		domains = append(domains, aws.StringValueSlice(res.Domains)...)
+
+		if aws.StringValue(res.NextToken) == "" {
+			return domains, nil
+		}
+		input.NextToken = res.NextToken
+	}
+}
@@ -0,0 +1,217 @@
+package rule
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/route53resolver/route53resolveriface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomains(t *testing.T) {
+	rules := []Rule{
+		{Name: "API.example.com.", Port: 443, SourceType: SourceTypeDNS},
+		{Name: "api.example.com", Port: 8443, SourceType: SourceTypeDNS},
+		{Name: "*.cdn.example.com", Port: 443, SourceType: SourceTypeDNS},
+		{Name: "S3", Port: 443, SourceType: "awsService"},
+		{Name: "office", Port: 22},
+	}
+
+	assert.Equal(t, []string{"*.cdn.example.com", "api.example.com"}, Domains(rules))
+}
+
+func TestDomainListApply(t *testing.T) {
+	sleep = func() {}
+
+	rules := []Rule{
+		{Name: "api.example.com", SourceType: SourceTypeDNS},
+		{Name: "www.example.com", SourceType: SourceTypeDNS},
+	}
+
+	tests := []struct {
+		name   string
+		opts   Options
+		client *fakeResolverClient
+
+		expectErr     bool
+		expectDomains []string
+		expectUpdates int
+	}{
+		{
+			name:   "AddAndRemove",
+			client: newFakeResolverClient("www.example.com.", "old.example.com."),
+
+			expectDomains: []string{"api.example.com.", "www.example.com."},
+			expectUpdates: 2,
+		},
+		{
+			name:   "InSync",
+			client: newFakeResolverClient("api.example.com.", "www.example.com."),
+
+			expectDomains: []string{"api.example.com.", "www.example.com."},
+		},
+		{
+			name: "WaitsForUpdate",
+			client: func() *fakeResolverClient {
+				c := newFakeResolverClient()
+				c.pending = 2
+				return c
+			}(),
+
+			expectDomains: []string{"api.example.com.", "www.example.com."},
+			expectUpdates: 1,
+		},
+		{
+			name: "Deleting",
+			client: func() *fakeResolverClient {
+				c := newFakeResolverClient()
+				c.status = route53resolver.FirewallDomainListStatusDeleting
+				return c
+			}(),
+
+			expectErr: true,
+		},
+		{
+			name:   "ExceedsMaxShrink",
+			opts:   Options{MaxShrinkPercent: 10},
+			client: newFakeResolverClient("api.example.com.", "old.example.com."),
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &DomainList{ID: "rslvr-fdl-123", Client: test.client}
+
+			err := target.Apply(rules, test.opts)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.expectDomains, test.client.sorted())
+			assert.Equal(t, test.expectUpdates, test.client.updates)
+		})
+	}
+}
+
+// fakeResolverClient is an in-memory domain list, which stores domains with a
+// trailing dot and pages one domain at a time.
+type fakeResolverClient struct {
+	route53resolveriface.Route53ResolverAPI
+
+	status  string
+	domains map[string]bool
+
+	// pending is the number of polls which report an update in progress.
+	pending int
+
+	// updates is the number of successful updates.
+	updates int
+}
+
+func newFakeResolverClient(domains ...string) *fakeResolverClient {
+	c := &fakeResolverClient{
+		status:  route53resolver.FirewallDomainListStatusComplete,
+		domains: make(map[string]bool),
+	}
+	for _, domain := range domains {
+		c.domains[domain] = true
+	}
+	return c
+}
+
+func (c *fakeResolverClient) sorted() []string {
+	domains := make([]string, 0, len(c.domains))
+	for domain := range c.domains {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+func (c *fakeResolverClient) GetFirewallDomainList(input *route53resolver.GetFirewallDomainListInput) (*route53resolver.GetFirewallDomainListOutput, error) {
+	status := c.status
+	if c.pending > 0 {
+		c.pending--
+		status = route53resolver.FirewallDomainListStatusUpdating
+	}
+
+	return &route53resolver.GetFirewallDomainListOutput{
+		FirewallDomainList: &route53resolver.FirewallDomainList{
+			Id:     input.FirewallDomainListId,
+			Status: aws.String(status),
+		},
+	}, nil
+}
+
+func (c *fakeResolverClient) ListFirewallDomains(input *route53resolver.ListFirewallDomainsInput) (*route53resolver.ListFirewallDomainsOutput, error) {
+	domains := c.sorted()
+
+	i := 0
+	if input.NextToken != nil {
+		i = sort.SearchStrings(domains, *input.NextToken)
+	}
+
+	out := &route53resolver.ListFirewallDomainsOutput{}
+	if i < len(domains) {
+		out.Domains = aws.StringSlice(domains[i : i+1])
+	}
+	if i+1 < len(domains) {
+		out.NextToken = aws.String(domains[i+1])
+	}
+	return out, nil
+}
+
+func (c *fakeResolverClient) UpdateFirewallDomains(input *route53resolver.UpdateFirewallDomainsInput) (*route53resolver.UpdateFirewallDomainsOutput, error) {
+	for _, domain := range aws.StringValueSlice(input.Domains) {
+		switch aws.StringValue(input.Operation) {
+		case route53resolver.FirewallDomainUpdateOperationAdd:
+			c.domains[domain+"."] = true
+		case route53resolver.FirewallDomainUpdateOperationRemove:
+			delete(c.domains, domain+".")
+		}
+	}
+	c.updates++
+
+	return &route53resolver.UpdateFirewallDomainsOutput{
+		Id:     input.FirewallDomainListId,
+		Status: aws.String(route53resolver.FirewallDomainListStatusUpdating),
+	}, nil
+}
+
+func TestDomainListNonDNSRules(t *testing.T) {
+	sleep = func() {}
+
+	rules := []Rule{{Name: "S3", SourceType: "awsService", CIDRs: []string{"10.0.0.0/24"}}}
+
+	client := newFakeResolverClient("api.example.com.", "www.example.com.")
+	target := &DomainList{ID: "rslvr-fdl-123", Client: client}
+
+	_, err := target.Plan(rules, Options{})
+	assert.Error(t, err)
+	assert.Error(t, target.Apply(rules, Options{}))
+	assert.Equal(t, []string{"api.example.com.", "www.example.com."}, client.sorted())
+	assert.Zero(t, client.updates)
+
+	assert.NoError(t, target.Apply(rules, Options{AllowEmptyDomains: true}))
+	assert.Empty(t, client.sorted())
+}
+
+func TestValidateDomainTargets(t *testing.T) {
+	targets := &Targets{DomainLists: []string{"rslvr-fdl-123"}}
+	assert.Error(t, targets.ValidateDomainTargets(false, Options{}))
+	assert.NoError(t, targets.ValidateDomainTargets(true, Options{}))
+	assert.NoError(t, targets.ValidateDomainTargets(false, Options{AllowEmptyDomains: true}))
+
+	targets = &Targets{RuleGroups: []string{"arn:aws:network-firewall:us-west-2:123456789012:stateful-rulegroup/egress"}}
+	assert.Error(t, targets.ValidateDomainTargets(false, Options{}))
+
+	targets = &Targets{SecurityGroups: []string{"sg-123"}}
+	assert.NoError(t, targets.ValidateDomainTargets(false, Options{}))
+}
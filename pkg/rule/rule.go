@@ -1,8 +1,10 @@
 package rule
 
 import (
+	"fmt"
 	"log"
 	"net"
+	"regexp"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -10,9 +12,13 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 )
 
-// DescriptionPrefix is the description to attach to a security group rule.
+// DescriptionPrefix is the description to attach to a security group rule
+// without an owner.
 const DescriptionPrefix = "AUTOGENERATED: "
 
+// ownerPattern matches valid owner IDs.
+var ownerPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
 // CIDRSuffix is the netmask for a single IP address.
 const CIDRSuffix = "/32"
 
@@ -23,6 +29,10 @@ const (
 	ProtoclICMP = "icmp"
 )
 
+// SourceTypeDNS is the source type of rules for DNS names. The names of these
+// rules are exported to domain list targets.
+const SourceTypeDNS = "dns"
+
 // Rule is one or more security group rules for a host.
 type Rule struct {
 	// Name is the FQDN.
@@ -39,6 +49,14 @@ type Rule struct {
 
 	// CIDRs is populated with the resolved FQDN.
 	CIDRs []string
+
+	// SourceType is the type of source the rule was generated from, such as
+	// dns. It is recorded in rule tags.
+	SourceType string `json:"-"`
+
+	// SyncToken is the syncToken of the IP ranges the rule was generated
+	// from, if any. It is recorded in rule tags.
+	SyncToken string `json:"-"`
 }
 
 // Resolve resolves the rule's name to IP addresses.
@@ -60,9 +78,63 @@ func (r *Rule) Resolve() ([]string, error) {
 	return cidrs, nil
 }
 
+// ResolveRules returns a copy of the rules of a dns-firewall event with their
+// CIDRs resolved, as DNS rules.
+func ResolveRules(rules []Rule) ([]Rule, error) {
+	resolved := make([]Rule, len(rules))
+	copy(resolved, rules)
+
+	for i := range resolved {
+		cidrs, err := resolved[i].Resolve()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %v", resolved[i].Name, err)
+		}
+		resolved[i].CIDRs = cidrs
+		resolved[i].SourceType = SourceTypeDNS
+	}
+
+	return resolved, nil
+}
+
+// ValidateOwner returns an error if owner is not a valid owner ID. The empty
+// owner is valid, and manages legacy rules without an owner.
+func ValidateOwner(owner string) error {
+	if owner != "" && !ownerPattern.MatchString(owner) {
+		return fmt.Errorf("invalid owner %q: must be 1-64 letters, digits, '.', '_' or '-'", owner)
+	}
+	return nil
+}
+
+// Description returns the description of an autogenerated rule for name,
+// such as "AUTOGENERATED[team-payments]: api.foo.com".
+func Description(owner, name string) string {
+	return descriptionPrefix(owner) + name
+}
+
+// descriptionPrefix returns the description prefix of rules belonging to
+// owner.
+func descriptionPrefix(owner string) string {
+	if owner == "" {
+		return DescriptionPrefix
+	}
+	return "AUTOGENERATED[" + owner + "]: "
+}
+
+// Owned returns a boolean for whether a rule description belongs to owner.
+// Rules of other owners, and manually created rules, are never modified.
+func Owned(description *string, owner string) bool {
+	return description != nil && strings.HasPrefix(*description, descriptionPrefix(owner))
+}
+
 // Exists returns a boolean for whether or not a given IP address in a rule
 // already exists in a security group.
 func Exists(ip string, rule Rule, sg *ec2.SecurityGroup) bool {
+	return find(ip, rule, sg) != nil
+}
+
+// find returns the IP range for a given IP address in a rule, or nil if it
+// does not exist in a security group.
+func find(ip string, rule Rule, sg *ec2.SecurityGroup) *ec2.IpRange {
 	var oldRules []*ec2.IpPermission
 	if rule.Egress {
 		oldRules = sg.IpPermissionsEgress
@@ -71,29 +143,43 @@ func Exists(ip string, rule Rule, sg *ec2.SecurityGroup) bool {
 	}
 
 	for _, oldRule := range oldRules {
-		if oldRule.FromPort == nil || oldRule.ToPort == nil {
-			continue
-		}
-		if int(*oldRule.FromPort) != rule.Port || int(*oldRule.ToPort) != rule.Port {
-			continue
-		}
-
-		if oldRule.IpProtocol == nil || *oldRule.IpProtocol != rule.Protocol {
+		if !matches(oldRule, rule) {
 			continue
 		}
 
 		for _, oldCIDR := range oldRule.IpRanges {
 			if oldCIDR.CidrIp != nil && *oldCIDR.CidrIp == ip {
-				return true
+				return oldCIDR
 			}
 		}
 	}
 
-	return false
+	return nil
 }
 
-// Add adds ingress and egress rules to a security group.
-func Add(rules []Rule, sg *ec2.SecurityGroup, ec2Client ec2iface.EC2API) error {
+// matches returns a boolean for whether a permission has the port and
+// protocol of a rule.
+func matches(permission *ec2.IpPermission, rule Rule) bool {
+	if permission.FromPort == nil || permission.ToPort == nil {
+		return false
+	}
+	if int(*permission.FromPort) != rule.Port || int(*permission.ToPort) != rule.Port {
+		return false
+	}
+
+	return permission.IpProtocol != nil && *permission.IpProtocol == rule.Protocol
+}
+
+// claimable returns a boolean for whether a rule description belongs to a
+// legacy rule without an owner which owner should take over.
+func claimable(description *string, owner string) bool {
+	return owner != "" && Owned(description, "")
+}
+
+// Add adds ingress and egress rules belonging to owner to a security group.
+// If owner is not empty, existing legacy rules without an owner which match
+// the rules are claimed by rewriting their descriptions.
+func Add(rules []Rule, sg *ec2.SecurityGroup, owner string, ec2Client ec2iface.EC2API) error {
 	egress := &ec2.AuthorizeSecurityGroupEgressInput{
 		GroupId:       sg.GroupId,
 		IpPermissions: []*ec2.IpPermission{},
@@ -104,6 +190,16 @@ func Add(rules []Rule, sg *ec2.SecurityGroup, ec2Client ec2iface.EC2API) error {
 		IpPermissions: []*ec2.IpPermission{},
 	}
 
+	claimEgress := &ec2.UpdateSecurityGroupRuleDescriptionsEgressInput{
+		GroupId:       sg.GroupId,
+		IpPermissions: []*ec2.IpPermission{},
+	}
+
+	claimIngress := &ec2.UpdateSecurityGroupRuleDescriptionsIngressInput{
+		GroupId:       sg.GroupId,
+		IpPermissions: []*ec2.IpPermission{},
+	}
+
 	for _, rule := range rules {
 		cidrs, err := rule.Resolve()
 		if err != nil {
@@ -113,15 +209,29 @@ func Add(rules []Rule, sg *ec2.SecurityGroup, ec2Client ec2iface.EC2API) error {
 		log.Printf("Resolved %s to %+v", rule.Name, cidrs)
 
 		ipRanges := make([]*ec2.IpRange, 0)
+		claimed := make([]*ec2.IpRange, 0)
 		for _, cidr := range cidrs {
-			if Exists(cidr, rule, sg) {
+			ipRange := &ec2.IpRange{
+				CidrIp:      aws.String(cidr),
+				Description: aws.String(Description(owner, rule.Name)),
+			}
+
+			if existing := find(cidr, rule, sg); existing != nil {
+				if claimable(existing.Description, owner) {
+					claimed = append(claimed, ipRange)
+				}
 				continue
 			}
 
-			ipRanges = append(ipRanges, &ec2.IpRange{
-				CidrIp:      aws.String(cidr),
-				Description: aws.String(DescriptionPrefix + rule.Name),
-			})
+			ipRanges = append(ipRanges, ipRange)
+		}
+
+		if len(claimed) > 0 {
+			if rule.Egress {
+				claimEgress.IpPermissions = append(claimEgress.IpPermissions, permission(rule, claimed))
+			} else {
+				claimIngress.IpPermissions = append(claimIngress.IpPermissions, permission(rule, claimed))
+			}
 		}
 
 		if len(ipRanges) <= 0 {
@@ -129,19 +239,27 @@ func Add(rules []Rule, sg *ec2.SecurityGroup, ec2Client ec2iface.EC2API) error {
 		}
 
 		if rule.Egress {
-			egress.IpPermissions = append(egress.IpPermissions, &ec2.IpPermission{
-				FromPort:   aws.Int64(int64(rule.Port)),
-				IpProtocol: aws.String(rule.Protocol),
-				IpRanges:   ipRanges,
-				ToPort:     aws.Int64(int64(rule.Port)),
-			})
+			egress.IpPermissions = append(egress.IpPermissions, permission(rule, ipRanges))
 		} else {
-			ingress.IpPermissions = append(ingress.IpPermissions, &ec2.IpPermission{
-				FromPort:   aws.Int64(int64(rule.Port)),
-				IpProtocol: aws.String(rule.Protocol),
-				IpRanges:   ipRanges,
-				ToPort:     aws.Int64(int64(rule.Port)),
-			})
+			ingress.IpPermissions = append(ingress.IpPermissions, permission(rule, ipRanges))
+		}
+	}
+
+	if len(claimEgress.IpPermissions) > 0 {
+		log.Printf("Claiming %d legacy egress rules for %s", len(claimEgress.IpPermissions), owner)
+
+		_, err := ec2Client.UpdateSecurityGroupRuleDescriptionsEgress(claimEgress)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(claimIngress.IpPermissions) > 0 {
+		log.Printf("Claiming %d legacy ingress rules for %s", len(claimIngress.IpPermissions), owner)
+
+		_, err := ec2Client.UpdateSecurityGroupRuleDescriptionsIngress(claimIngress)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -170,55 +288,27 @@ func Add(rules []Rule, sg *ec2.SecurityGroup, ec2Client ec2iface.EC2API) error {
 	return nil
 }
 
-// Cleanup removes rules from a security group which are *not* in the
-// provided list of rules.
-func Cleanup(rules []Rule, sg *ec2.SecurityGroup, ec2Client ec2iface.EC2API) error {
+// permission returns a permission for the port and protocol of a rule.
+func permission(rule Rule, ipRanges []*ec2.IpRange) *ec2.IpPermission {
+	return &ec2.IpPermission{
+		FromPort:   aws.Int64(int64(rule.Port)),
+		IpProtocol: aws.String(rule.Protocol),
+		IpRanges:   ipRanges,
+		ToPort:     aws.Int64(int64(rule.Port)),
+	}
+}
+
+// Cleanup removes rules belonging to owner from a security group which are
+// *not* in the provided list of rules.
+func Cleanup(rules []Rule, sg *ec2.SecurityGroup, owner string, ec2Client ec2iface.EC2API) error {
 	egress := &ec2.RevokeSecurityGroupEgressInput{
 		GroupId:       sg.GroupId,
-		IpPermissions: []*ec2.IpPermission{},
+		IpPermissions: stale(rules, sg.IpPermissionsEgress, true, owner),
 	}
 
 	ingress := &ec2.RevokeSecurityGroupIngressInput{
 		GroupId:       sg.GroupId,
-		IpPermissions: []*ec2.IpPermission{},
-	}
-
-EGRESS_OUTER:
-	for _, oldRule := range sg.IpPermissionsEgress {
-		for _, oldCIDR := range oldRule.IpRanges {
-			if oldCIDR.Description == nil || !strings.HasPrefix(*oldCIDR.Description, DescriptionPrefix) {
-				continue EGRESS_OUTER
-			}
-
-			for _, newRule := range rules {
-				for _, cidr := range newRule.CIDRs {
-					if oldCIDR.CidrIp != nil && *oldCIDR.CidrIp == cidr {
-						continue EGRESS_OUTER
-					}
-				}
-			}
-		}
-
-		egress.IpPermissions = append(egress.IpPermissions, oldRule)
-	}
-
-INGRESS_OUTER:
-	for _, oldRule := range sg.IpPermissions {
-		for _, oldCIDR := range oldRule.IpRanges {
-			if oldCIDR.Description == nil || !strings.HasPrefix(*oldCIDR.Description, DescriptionPrefix) {
-				continue INGRESS_OUTER
-			}
-
-			for _, newRule := range rules {
-				for _, cidr := range newRule.CIDRs {
-					if oldCIDR.CidrIp != nil && *oldCIDR.CidrIp == cidr {
-						continue INGRESS_OUTER
-					}
-				}
-			}
-		}
-
-		ingress.IpPermissions = append(ingress.IpPermissions, oldRule)
+		IpPermissions: stale(rules, sg.IpPermissions, false, owner),
 	}
 
 	if len(egress.IpPermissions) > 0 {
@@ -245,3 +335,139 @@ INGRESS_OUTER:
 
 	return nil
 }
+
+// InSync returns a boolean for whether a security group already contains
+// every CIDR in the provided rules, owned by owner, and no rules belonging to
+// owner which Cleanup would remove. The rules must already be resolved.
+func InSync(rules []Rule, sg *ec2.SecurityGroup, owner string) bool {
+	for _, rule := range rules {
+		for _, cidr := range rule.CIDRs {
+			existing := find(cidr, rule, sg)
+			if existing == nil || claimable(existing.Description, owner) {
+				return false
+			}
+		}
+	}
+
+	return len(stale(rules, sg.IpPermissionsEgress, true, owner)) == 0 &&
+		len(stale(rules, sg.IpPermissions, false, owner)) == 0
+}
+
+// changes returns the changes which Add and Cleanup make to a security group.
+// The rules must already be resolved.
+func changes(rules []Rule, sg *ec2.SecurityGroup, owner string) []Change {
+	sgid := aws.StringValue(sg.GroupId)
+	result := make([]Change, 0)
+
+	for _, rule := range rules {
+		for _, cidr := range rule.CIDRs {
+			existing := find(cidr, rule, sg)
+			switch {
+			case existing == nil:
+				result = append(result, ruleChange(sgid, ChangeAdd, rule, cidr, Description(owner, rule.Name)))
+			case claimable(existing.Description, owner):
+				result = append(result, ruleChange(sgid, ChangeUpdate, rule, cidr, Description(owner, rule.Name)))
+			}
+		}
+	}
+
+	result = append(result, permissionChanges(sgid, ChangeRemove, stale(rules, sg.IpPermissionsEgress, true, owner), true)...)
+	result = append(result, permissionChanges(sgid, ChangeRemove, stale(rules, sg.IpPermissions, false, owner), false)...)
+
+	return result
+}
+
+// CheckShrink returns an error if Cleanup would remove more than maxPercent of
+// the CIDRs belonging to owner in a security group. This guards against
+// applying a truncated or otherwise bad list of rules. A maxPercent of zero
+// disables the check.
+func CheckShrink(rules []Rule, sg *ec2.SecurityGroup, owner string, maxPercent float64) error {
+	if maxPercent <= 0 {
+		return nil
+	}
+
+	existing := countOwned(sg.IpPermissionsEgress, owner) + countOwned(sg.IpPermissions, owner)
+	if existing == 0 {
+		return nil
+	}
+
+	removed := countOwned(stale(rules, sg.IpPermissionsEgress, true, owner), owner) +
+		countOwned(stale(rules, sg.IpPermissions, false, owner), owner)
+
+	return checkShrink(removed, existing, aws.StringValue(sg.GroupId), maxPercent)
+}
+
+// checkShrink returns an error if removing removed of existing CIDRs exceeds
+// maxPercent.
+func checkShrink(removed, existing int, sgid string, maxPercent float64) error {
+	if maxPercent <= 0 || existing == 0 {
+		return nil
+	}
+
+	percent := float64(removed) * 100 / float64(existing)
+	if percent > maxPercent {
+		return fmt.Errorf("refusing to remove %d of %d autogenerated CIDRs (%.1f%%) from %s, which exceeds %.1f%%",
+			removed, existing, percent, sgid, maxPercent)
+	}
+
+	return nil
+}
+
+// countOwned returns the number of CIDRs belonging to owner in a list of
+// permissions.
+func countOwned(permissions []*ec2.IpPermission, owner string) int {
+	count := 0
+	for _, permission := range permissions {
+		for _, cidr := range permission.IpRanges {
+			if Owned(cidr.Description, owner) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// stale returns the CIDRs belonging to owner in a list of ingress or egress
+// permissions which are *not* in the provided list of rules, grouped by
+// permission.
+func stale(rules []Rule, permissions []*ec2.IpPermission, egress bool, owner string) []*ec2.IpPermission {
+	result := []*ec2.IpPermission{}
+
+	for _, oldRule := range permissions {
+		ipRanges := make([]*ec2.IpRange, 0)
+
+	CIDRS:
+		for _, oldCIDR := range oldRule.IpRanges {
+			if !Owned(oldCIDR.Description, owner) {
+				continue
+			}
+
+			for _, newRule := range rules {
+				if newRule.Egress != egress || !matches(oldRule, newRule) {
+					continue
+				}
+
+				for _, cidr := range newRule.CIDRs {
+					if oldCIDR.CidrIp != nil && *oldCIDR.CidrIp == cidr {
+						continue CIDRS
+					}
+				}
+			}
+
+			ipRanges = append(ipRanges, oldCIDR)
+		}
+
+		if len(ipRanges) == 0 {
+			continue
+		}
+
+		result = append(result, &ec2.IpPermission{
+			FromPort:   oldRule.FromPort,
+			IpProtocol: oldRule.IpProtocol,
+			IpRanges:   ipRanges,
+			ToPort:     oldRule.ToPort,
+		})
+	}
+
+	return result
+}
@@ -2,6 +2,7 @@ package rule
 
 import (
 	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -210,16 +211,49 @@ func TestExists(t *testing.T) {
 	}
 }
 
+func TestResolveRules(t *testing.T) {
+	rules := []Rule{
+		{Name: "api.example.com", Port: 443, Protocol: ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32", "2001:db8::1/128"}},
+		{Name: "ping.example.com", Port: -1, Protocol: ProtoclICMP, Egress: true, CIDRs: []string{"198.51.100.0/24"}},
+	}
+
+	resolved, err := ResolveRules(rules)
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Name: "api.example.com", Port: 443, Protocol: ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32", "2001:db8::1/128"}, SourceType: SourceTypeDNS},
+		{Name: "ping.example.com", Port: -1, Protocol: ProtoclICMP, Egress: true, CIDRs: []string{"198.51.100.0/24"}, SourceType: SourceTypeDNS},
+	}, resolved)
+	assert.Empty(t, rules[0].SourceType)
+}
+
+func TestValidateOwner(t *testing.T) {
+	assert.NoError(t, ValidateOwner(""))
+	assert.NoError(t, ValidateOwner("team-payments"))
+	assert.Error(t, ValidateOwner("team]payments"))
+	assert.Error(t, ValidateOwner("team payments"))
+}
+
+func TestOwned(t *testing.T) {
+	assert.True(t, Owned(aws.String("AUTOGENERATED: api.foo.com"), ""))
+	assert.True(t, Owned(aws.String("AUTOGENERATED[team-payments]: api.foo.com"), "team-payments"))
+	assert.False(t, Owned(aws.String("AUTOGENERATED[team-payments]: api.foo.com"), ""))
+	assert.False(t, Owned(aws.String("AUTOGENERATED: api.foo.com"), "team-payments"))
+	assert.False(t, Owned(aws.String("AUTOGENERATED[team-payments-2]: api.foo.com"), "team-payments"))
+	assert.False(t, Owned(nil, ""))
+}
+
 func TestAdd(t *testing.T) {
 	tests := []struct {
 		name      string
 		rules     []Rule
+		owner     string
 		sg        *ec2.SecurityGroup
 		ec2Client *mockEC2Client
 
 		expectErr         bool
 		expectEgressCall  *ec2.AuthorizeSecurityGroupEgressInput
 		expectIngressCall *ec2.AuthorizeSecurityGroupIngressInput
+		expectClaimCall   *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput
 	}{
 		{
 			name: "AddSingleEgressRule",
@@ -366,6 +400,117 @@ func TestAdd(t *testing.T) {
 			},
 			ec2Client: &mockEC2Client{},
 		},
+		{
+			name: "AddOwnedRule",
+			rules: []Rule{
+				{
+					Name:     "api.foo.com",
+					Port:     443,
+					Protocol: ProtocolTCP,
+					Egress:   true,
+					CIDRs:    []string{"123.123.123.123/32"},
+				},
+			},
+			owner: "team-payments",
+			sg: &ec2.SecurityGroup{
+				GroupId: aws.String("sg-123"),
+			},
+			ec2Client: &mockEC2Client{},
+
+			expectEgressCall: &ec2.AuthorizeSecurityGroupEgressInput{
+				GroupId: aws.String("sg-123"),
+				IpPermissions: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED[team-payments]: api.foo.com"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ClaimLegacyRule",
+			rules: []Rule{
+				{
+					Name:     "api.foo.com",
+					Port:     443,
+					Protocol: ProtocolTCP,
+					Egress:   true,
+					CIDRs:    []string{"123.123.123.123/32"},
+				},
+			},
+			owner: "team-payments",
+			sg: &ec2.SecurityGroup{
+				GroupId: aws.String("sg-123"),
+				IpPermissionsEgress: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED: api.foo.com"),
+							},
+						},
+					},
+				},
+			},
+			ec2Client: &mockEC2Client{},
+
+			expectClaimCall: &ec2.UpdateSecurityGroupRuleDescriptionsEgressInput{
+				GroupId: aws.String("sg-123"),
+				IpPermissions: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED[team-payments]: api.foo.com"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "OtherOwnersRuleExists",
+			rules: []Rule{
+				{
+					Name:     "api.foo.com",
+					Port:     443,
+					Protocol: ProtocolTCP,
+					Egress:   true,
+					CIDRs:    []string{"123.123.123.123/32"},
+				},
+			},
+			owner: "team-payments",
+			sg: &ec2.SecurityGroup{
+				GroupId: aws.String("sg-123"),
+				IpPermissionsEgress: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED[team-search]: api.foo.com"),
+							},
+						},
+					},
+				},
+			},
+			ec2Client: &mockEC2Client{},
+		},
 		{
 			name: "AddRuleError",
 			rules: []Rule{
@@ -392,8 +537,9 @@ func TestAdd(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			test.ec2Client.AuthorizeSecurityGroupEgressCalls = make(chan *ec2.AuthorizeSecurityGroupEgressInput, 1)
 			test.ec2Client.AuthorizeSecurityGroupIngressCalls = make(chan *ec2.AuthorizeSecurityGroupIngressInput, 1)
+			test.ec2Client.UpdateSecurityGroupRuleDescriptionsEgressCalls = make(chan *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput, 1)
 
-			err := Add(test.rules, test.sg, test.ec2Client)
+			err := Add(test.rules, test.sg, test.owner, test.ec2Client)
 
 			if test.expectErr {
 				assert.Error(t, err)
@@ -418,6 +564,15 @@ func TestAdd(t *testing.T) {
 					t.Fatal("Expected ingress rules to be added")
 				}
 			}
+
+			select {
+			case call := <-test.ec2Client.UpdateSecurityGroupRuleDescriptionsEgressCalls:
+				assert.EqualValues(t, test.expectClaimCall, call)
+			default:
+				if test.expectClaimCall != nil {
+					t.Fatal("Expected egress rules to be claimed")
+				}
+			}
 		})
 	}
 }
@@ -426,6 +581,7 @@ func TestCleanup(t *testing.T) {
 	tests := []struct {
 		name      string
 		rules     []Rule
+		owner     string
 		sg        *ec2.SecurityGroup
 		ec2Client *mockEC2Client
 
@@ -592,6 +748,109 @@ func TestCleanup(t *testing.T) {
 			},
 			ec2Client: &mockEC2Client{},
 		},
+		{
+			name: "RevokeOnlyOwnedRules",
+			rules: []Rule{
+				{
+					Port:     443,
+					Protocol: ProtocolTCP,
+					Egress:   true,
+					CIDRs:    []string{"123.123.123.123/32"},
+				},
+			},
+			owner: "team-payments",
+			sg: &ec2.SecurityGroup{
+				GroupId: aws.String("sg-123"),
+				IpPermissionsEgress: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED[team-payments]: api.foo.com"),
+							},
+							{
+								CidrIp:      aws.String("123.123.123.124/32"),
+								Description: aws.String("AUTOGENERATED[team-payments]: api.foo.com"),
+							},
+							{
+								CidrIp:      aws.String("123.123.123.125/32"),
+								Description: aws.String("AUTOGENERATED[team-search]: api.bar.com"),
+							},
+							{
+								CidrIp:      aws.String("123.123.123.126/32"),
+								Description: aws.String("AUTOGENERATED: api.baz.com"),
+							},
+						},
+					},
+				},
+			},
+			ec2Client: &mockEC2Client{},
+
+			expectEgressCall: &ec2.RevokeSecurityGroupEgressInput{
+				GroupId: aws.String("sg-123"),
+				IpPermissions: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.124/32"),
+								Description: aws.String("AUTOGENERATED[team-payments]: api.foo.com"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RevokePortMismatch",
+			rules: []Rule{
+				{
+					Port:     8443,
+					Protocol: ProtocolTCP,
+					Egress:   true,
+					CIDRs:    []string{"123.123.123.123/32"},
+				},
+			},
+			sg: &ec2.SecurityGroup{
+				GroupId: aws.String("sg-123"),
+				IpPermissionsEgress: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED: api.foo.com"),
+							},
+						},
+					},
+				},
+			},
+			ec2Client: &mockEC2Client{},
+
+			expectEgressCall: &ec2.RevokeSecurityGroupEgressInput{
+				GroupId: aws.String("sg-123"),
+				IpPermissions: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED: api.foo.com"),
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Error",
 			sg: &ec2.SecurityGroup{
@@ -623,7 +882,7 @@ func TestCleanup(t *testing.T) {
 			test.ec2Client.RevokeSecurityGroupEgressCalls = make(chan *ec2.RevokeSecurityGroupEgressInput, 1)
 			test.ec2Client.RevokeSecurityGroupIngressCalls = make(chan *ec2.RevokeSecurityGroupIngressInput, 1)
 
-			err := Cleanup(test.rules, test.sg, test.ec2Client)
+			err := Cleanup(test.rules, test.sg, test.owner, test.ec2Client)
 
 			if test.expectErr {
 				assert.Error(t, err)
@@ -652,6 +911,194 @@ func TestCleanup(t *testing.T) {
 	}
 }
 
+func TestInSync(t *testing.T) {
+	autogenerated := func(cidr string) *ec2.IpPermission {
+		return &ec2.IpPermission{
+			FromPort:   aws.Int64(443),
+			ToPort:     aws.Int64(443),
+			IpProtocol: aws.String(ProtocolTCP),
+			IpRanges: []*ec2.IpRange{
+				{
+					CidrIp:      aws.String(cidr),
+					Description: aws.String("AUTOGENERATED: api.foo.com"),
+				},
+			},
+		}
+	}
+
+	rules := []Rule{
+		{
+			Name:     "api.foo.com",
+			Port:     443,
+			Protocol: ProtocolTCP,
+			Egress:   true,
+			CIDRs:    []string{"123.123.123.123/32"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		owner string
+		sg    *ec2.SecurityGroup
+
+		expect bool
+	}{
+		{
+			name: "InSync",
+			sg: &ec2.SecurityGroup{
+				IpPermissionsEgress: []*ec2.IpPermission{
+					autogenerated("123.123.123.123/32"),
+				},
+			},
+			expect: true,
+		},
+		{
+			name:   "MissingRule",
+			sg:     &ec2.SecurityGroup{},
+			expect: false,
+		},
+		{
+			name: "StaleRule",
+			sg: &ec2.SecurityGroup{
+				IpPermissionsEgress: []*ec2.IpPermission{
+					autogenerated("123.123.123.123/32"),
+					autogenerated("123.123.123.124/32"),
+				},
+			},
+			expect: false,
+		},
+		{
+			name:  "LegacyRuleNotClaimed",
+			owner: "team-payments",
+			sg: &ec2.SecurityGroup{
+				IpPermissionsEgress: []*ec2.IpPermission{
+					autogenerated("123.123.123.123/32"),
+				},
+			},
+			expect: false,
+		},
+		{
+			name:  "OtherOwnersStaleRule",
+			owner: "team-payments",
+			sg: &ec2.SecurityGroup{
+				IpPermissionsEgress: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("123.123.123.123/32"),
+								Description: aws.String("AUTOGENERATED[team-payments]: api.foo.com"),
+							},
+							{
+								CidrIp:      aws.String("123.123.123.124/32"),
+								Description: aws.String("AUTOGENERATED[team-search]: api.bar.com"),
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "StaleIngressRule",
+			sg: &ec2.SecurityGroup{
+				IpPermissions: []*ec2.IpPermission{
+					autogenerated("123.123.123.124/32"),
+				},
+				IpPermissionsEgress: []*ec2.IpPermission{
+					autogenerated("123.123.123.123/32"),
+				},
+			},
+			expect: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, InSync(rules, test.sg, test.owner))
+		})
+	}
+}
+
+func TestCheckShrink(t *testing.T) {
+	autogenerated := func(cidr string) *ec2.IpPermission {
+		return &ec2.IpPermission{
+			FromPort:   aws.Int64(443),
+			ToPort:     aws.Int64(443),
+			IpProtocol: aws.String(ProtocolTCP),
+			IpRanges: []*ec2.IpRange{
+				{
+					CidrIp:      aws.String(cidr),
+					Description: aws.String("AUTOGENERATED: AMAZON"),
+				},
+			},
+		}
+	}
+
+	sg := &ec2.SecurityGroup{
+		GroupId: aws.String("sg-123"),
+		IpPermissionsEgress: []*ec2.IpPermission{
+			autogenerated("10.0.0.0/24"),
+			autogenerated("10.0.1.0/24"),
+			autogenerated("10.0.2.0/24"),
+			autogenerated("10.0.3.0/24"),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cidrs      []string
+		maxPercent float64
+
+		expectErr bool
+	}{
+		{
+			name:       "Disabled",
+			maxPercent: 0,
+		},
+		{
+			name:       "WithinLimit",
+			cidrs:      []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"},
+			maxPercent: 25,
+		},
+		{
+			name:       "ExceedsLimit",
+			cidrs:      []string{"10.0.0.0/24", "10.0.1.0/24"},
+			maxPercent: 25,
+			expectErr:  true,
+		},
+		{
+			name:       "EverythingRemoved",
+			maxPercent: 99,
+			expectErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules := []Rule{
+				{
+					Name:     "AMAZON",
+					Port:     443,
+					Protocol: ProtocolTCP,
+					Egress:   true,
+					CIDRs:    test.cidrs,
+				},
+			}
+
+			err := CheckShrink(rules, sg, "", test.maxPercent)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 type mockEC2Client struct {
 	ec2iface.EC2API
 
@@ -661,6 +1108,13 @@ type mockEC2Client struct {
 	RevokeSecurityGroupEgressCalls  chan *ec2.RevokeSecurityGroupEgressInput
 	RevokeSecurityGroupIngressCalls chan *ec2.RevokeSecurityGroupIngressInput
 
+	UpdateSecurityGroupRuleDescriptionsEgressCalls chan *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput
+
+	SecurityGroupRules            []*ec2.SecurityGroupRule
+	DescribeSecurityGroupRulesErr error
+	CreateTagsCalls               []*ec2.CreateTagsInput
+	ModifySecurityGroupRulesCalls []*ec2.ModifySecurityGroupRulesInput
+
 	Err error
 }
 
@@ -683,3 +1137,38 @@ func (m *mockEC2Client) RevokeSecurityGroupIngress(input *ec2.RevokeSecurityGrou
 	m.RevokeSecurityGroupIngressCalls <- input
 	return nil, m.Err
 }
+
+func (m *mockEC2Client) UpdateSecurityGroupRuleDescriptionsEgress(input *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput, error) {
+	m.UpdateSecurityGroupRuleDescriptionsEgressCalls <- input
+	return nil, m.Err
+}
+
+func (m *mockEC2Client) DescribeSecurityGroupRules(input *ec2.DescribeSecurityGroupRulesInput) (*ec2.DescribeSecurityGroupRulesOutput, error) {
+	if m.DescribeSecurityGroupRulesErr != nil {
+		return nil, m.DescribeSecurityGroupRulesErr
+	}
+
+	// Each rule is returned on its own page.
+	i := 0
+	if input.NextToken != nil {
+		i, _ = strconv.Atoi(*input.NextToken)
+	}
+	if i >= len(m.SecurityGroupRules) {
+		return &ec2.DescribeSecurityGroupRulesOutput{}, nil
+	}
+
+	return &ec2.DescribeSecurityGroupRulesOutput{
+		SecurityGroupRules: m.SecurityGroupRules[i : i+1],
+		NextToken:          aws.String(strconv.Itoa(i + 1)),
+	}, nil
+}
+
+func (m *mockEC2Client) CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	m.CreateTagsCalls = append(m.CreateTagsCalls, input)
+	return nil, m.Err
+}
+
+func (m *mockEC2Client) ModifySecurityGroupRules(input *ec2.ModifySecurityGroupRulesInput) (*ec2.ModifySecurityGroupRulesOutput, error) {
+	m.ModifySecurityGroupRulesCalls = append(m.ModifySecurityGroupRulesCalls, input)
+	return nil, m.Err
+}
@@ -0,0 +1,383 @@
+package rule
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// Tag keys of security group rules managed with tags.
+const (
+	TagOwner      = "dsg:owner"
+	TagRule       = "dsg:rule"
+	TagSourceType = "dsg:source-type"
+	TagFirstSeen  = "dsg:first-seen"
+	TagLastSeen   = "dsg:last-seen"
+	TagSyncToken  = "dsg:sync-token"
+)
+
+// ErrTagsUnsupported is returned when the EC2 API does not support security
+// group rule IDs and tags.
+var ErrTagsUnsupported = errors.New("security group rule tags are not supported")
+
+// unsupportedCodes are the error codes returned by EC2 APIs which do not
+// support security group rules.
+var unsupportedCodes = map[string]bool{
+	"InvalidAction":        true,
+	"UnsupportedOperation": true,
+	"NotImplemented":       true,
+}
+
+// now returns the current time, and is replaced in tests.
+var now = time.Now
+
+// DescribeRules describes every rule in a security group. ErrTagsUnsupported
+// is returned if the EC2 API does not support security group rules.
+func DescribeRules(sgid string, ec2Client ec2iface.EC2API) ([]*ec2.SecurityGroupRule, error) {
+	input := &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("group-id"),
+				Values: []*string{aws.String(sgid)},
+			},
+		},
+	}
+
+	rules := make([]*ec2.SecurityGroupRule, 0)
+	for {
+		res, err := ec2Client.DescribeSecurityGroupRules(input)
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && unsupportedCodes[aerr.Code()] {
+				return nil, ErrTagsUnsupported
+			}
+			return nil, err
+		}
+
+		rules = append(rules, res.SecurityGroupRules...)
+
+		if aws.StringValue(res.NextToken) == "" {
+			return rules, nil
+		}
+		input.NextToken = res.NextToken
+	}
+}
+
+// taggedPlan is the set of changes which SyncTagged makes to a security group.
+type taggedPlan struct {
+	// add are the rules to authorize, with only their missing CIDRs.
+	add []Rule
+
+	// adopt are existing rules without tags which belong to the owner,
+	// mapped to the rules they match.
+	adopt map[*ec2.SecurityGroupRule]Rule
+
+	// touch are the IDs of existing tagged rules which are still wanted,
+	// grouped by syncToken.
+	touch map[string][]*string
+
+	// modify are existing rules whose descriptions are out of date.
+	modify []*ec2.SecurityGroupRuleUpdate
+
+	// revokeEgress and revokeIngress are the IDs of stale rules.
+	revokeEgress  []*string
+	revokeIngress []*string
+
+	// owned is the number of existing rules belonging to the owner.
+	owned int
+}
+
+// tagValue returns the value of a tag, and whether it is set.
+func tagValue(tags []*ec2.Tag, key string) (string, bool) {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return aws.StringValue(tag.Value), true
+		}
+	}
+	return "", false
+}
+
+// ownership returns whether an existing rule is tagged, and whether it
+// belongs to owner. Rules without an owner tag belong to owner if their
+// description does, or if they are legacy rules which owner may claim.
+func ownership(sgr *ec2.SecurityGroupRule, owner string) (tagged bool, owned bool, legacy bool) {
+	if value, ok := tagValue(sgr.Tags, TagOwner); ok {
+		return true, value == owner, false
+	}
+
+	return false, Owned(sgr.Description, owner), claimable(sgr.Description, owner)
+}
+
+// matchesRule returns a boolean for whether an existing rule allows a CIDR
+// in a rule.
+func matchesRule(sgr *ec2.SecurityGroupRule, rule Rule, cidr string) bool {
+	return aws.BoolValue(sgr.IsEgress) == rule.Egress &&
+		aws.StringValue(sgr.IpProtocol) == rule.Protocol &&
+		sgr.FromPort != nil && int(*sgr.FromPort) == rule.Port &&
+		sgr.ToPort != nil && int(*sgr.ToPort) == rule.Port &&
+		aws.StringValue(sgr.CidrIpv4) == cidr
+}
+
+// planTagged compares rules with the existing rules in a security group.
+func planTagged(rules []Rule, existing []*ec2.SecurityGroupRule, owner string) *taggedPlan {
+	p := &taggedPlan{
+		adopt: make(map[*ec2.SecurityGroupRule]Rule),
+		touch: make(map[string][]*string),
+	}
+
+	wanted := make(map[*ec2.SecurityGroupRule]bool)
+
+	for _, rule := range rules {
+		missing := make([]string, 0)
+
+	CIDRS:
+		for _, cidr := range rule.CIDRs {
+			for _, sgr := range existing {
+				if !matchesRule(sgr, rule, cidr) {
+					continue
+				}
+
+				wanted[sgr] = true
+
+				tagged, owned, legacy := ownership(sgr, owner)
+				switch {
+				case tagged && owned:
+					p.touch[rule.SyncToken] = append(p.touch[rule.SyncToken], sgr.SecurityGroupRuleId)
+				case owned || legacy:
+					p.adopt[sgr] = rule
+				default:
+					// Rules of other owners and manually created rules
+					// are left alone.
+					continue CIDRS
+				}
+
+				if description := Description(owner, rule.Name); aws.StringValue(sgr.Description) != description {
+					p.modify = append(p.modify, &ec2.SecurityGroupRuleUpdate{
+						SecurityGroupRuleId: sgr.SecurityGroupRuleId,
+						SecurityGroupRule: &ec2.SecurityGroupRuleRequest{
+							CidrIpv4:    sgr.CidrIpv4,
+							Description: aws.String(description),
+							FromPort:    sgr.FromPort,
+							IpProtocol:  sgr.IpProtocol,
+							ToPort:      sgr.ToPort,
+						},
+					})
+				}
+
+				continue CIDRS
+			}
+
+			missing = append(missing, cidr)
+		}
+
+		if len(missing) > 0 {
+			add := rule
+			add.CIDRs = missing
+			p.add = append(p.add, add)
+		}
+	}
+
+	for _, sgr := range existing {
+		if _, owned, _ := ownership(sgr, owner); !owned {
+			continue
+		}
+		p.owned++
+
+		if wanted[sgr] {
+			continue
+		}
+
+		if aws.BoolValue(sgr.IsEgress) {
+			p.revokeEgress = append(p.revokeEgress, sgr.SecurityGroupRuleId)
+		} else {
+			p.revokeIngress = append(p.revokeIngress, sgr.SecurityGroupRuleId)
+		}
+	}
+
+	return p
+}
+
+// changes returns the changes in the plan. Rules whose last-seen tags are
+// only refreshed are not changes.
+func (p *taggedPlan) changes(sgid, owner string, existing []*ec2.SecurityGroupRule) []Change {
+	result := make([]Change, 0)
+
+	for _, rule := range p.add {
+		for _, cidr := range rule.CIDRs {
+			result = append(result, ruleChange(sgid, ChangeAdd, rule, cidr, Description(owner, rule.Name)))
+		}
+	}
+
+	updated := make(map[string]bool)
+	for sgr, rule := range p.adopt {
+		updated[aws.StringValue(sgr.SecurityGroupRuleId)] = true
+		result = append(result, ruleChange(sgid, ChangeUpdate, rule, aws.StringValue(sgr.CidrIpv4), Description(owner, rule.Name)))
+	}
+
+	byID := make(map[string]*ec2.SecurityGroupRule)
+	for _, sgr := range existing {
+		byID[aws.StringValue(sgr.SecurityGroupRuleId)] = sgr
+	}
+
+	for _, update := range p.modify {
+		id := aws.StringValue(update.SecurityGroupRuleId)
+		if updated[id] {
+			continue
+		}
+		updated[id] = true
+
+		change := securityGroupRuleChange(sgid, ChangeUpdate, byID[id])
+		change.Description = aws.StringValue(update.SecurityGroupRule.Description)
+		result = append(result, change)
+	}
+
+	for _, id := range append(append([]*string{}, p.revokeEgress...), p.revokeIngress...) {
+		result = append(result, securityGroupRuleChange(sgid, ChangeRemove, byID[aws.StringValue(id)]))
+	}
+
+	return result
+}
+
+// ruleTags returns the tags of a new or adopted rule.
+func ruleTags(rule Rule, owner, seen string) []*ec2.Tag {
+	tags := []*ec2.Tag{
+		{Key: aws.String(TagOwner), Value: aws.String(owner)},
+		{Key: aws.String(TagRule), Value: aws.String(rule.Name)},
+		{Key: aws.String(TagFirstSeen), Value: aws.String(seen)},
+		{Key: aws.String(TagLastSeen), Value: aws.String(seen)},
+	}
+
+	if rule.SourceType != "" {
+		tags = append(tags, &ec2.Tag{Key: aws.String(TagSourceType), Value: aws.String(rule.SourceType)})
+	}
+	if rule.SyncToken != "" {
+		tags = append(tags, &ec2.Tag{Key: aws.String(TagSyncToken), Value: aws.String(rule.SyncToken)})
+	}
+
+	return tags
+}
+
+// SyncTagged adds rules to a security group and removes the stale rules
+// belonging to the owner, tracking ownership and metadata with security group
+// rule tags. Untagged rules whose descriptions belong to the owner are
+// adopted. ErrTagsUnsupported is returned, before any changes are made, if
+// the EC2 API does not support security group rules.
+func SyncTagged(rules []Rule, sgid string, opts Options, ec2Client ec2iface.EC2API) error {
+	existing, err := DescribeRules(sgid, ec2Client)
+	if err != nil {
+		return err
+	}
+
+	p := planTagged(rules, existing, opts.Owner)
+
+	if err := checkShrink(len(p.revokeEgress)+len(p.revokeIngress), p.owned, sgid, opts.MaxShrinkPercent); err != nil {
+		return err
+	}
+
+	seen := now().UTC().Format(time.RFC3339)
+
+	for _, rule := range p.add {
+		ipRanges := make([]*ec2.IpRange, len(rule.CIDRs))
+		for i, cidr := range rule.CIDRs {
+			ipRanges[i] = &ec2.IpRange{
+				CidrIp:      aws.String(cidr),
+				Description: aws.String(Description(opts.Owner, rule.Name)),
+			}
+		}
+
+		tagSpecs := []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeSecurityGroupRule),
+				Tags:         ruleTags(rule, opts.Owner, seen),
+			},
+		}
+
+		log.Printf("Adding %d CIDRs for %s to %s", len(ipRanges), rule.Name, sgid)
+
+		if rule.Egress {
+			_, err = ec2Client.AuthorizeSecurityGroupEgress(&ec2.AuthorizeSecurityGroupEgressInput{
+				GroupId:           aws.String(sgid),
+				IpPermissions:     []*ec2.IpPermission{permission(rule, ipRanges)},
+				TagSpecifications: tagSpecs,
+			})
+		} else {
+			_, err = ec2Client.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+				GroupId:           aws.String(sgid),
+				IpPermissions:     []*ec2.IpPermission{permission(rule, ipRanges)},
+				TagSpecifications: tagSpecs,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for sgr, rule := range p.adopt {
+		log.Printf("Adopting rule %s for %s", aws.StringValue(sgr.SecurityGroupRuleId), rule.Name)
+
+		_, err := ec2Client.CreateTags(&ec2.CreateTagsInput{
+			Resources: []*string{sgr.SecurityGroupRuleId},
+			Tags:      ruleTags(rule, opts.Owner, seen),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(p.modify) > 0 {
+		log.Printf("Updating the descriptions of %d rules in %s", len(p.modify), sgid)
+
+		_, err := ec2Client.ModifySecurityGroupRules(&ec2.ModifySecurityGroupRulesInput{
+			GroupId:            aws.String(sgid),
+			SecurityGroupRules: p.modify,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for syncToken, ids := range p.touch {
+		tags := []*ec2.Tag{{Key: aws.String(TagLastSeen), Value: aws.String(seen)}}
+		if syncToken != "" {
+			tags = append(tags, &ec2.Tag{Key: aws.String(TagSyncToken), Value: aws.String(syncToken)})
+		}
+
+		_, err := ec2Client.CreateTags(&ec2.CreateTagsInput{
+			Resources: ids,
+			Tags:      tags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(p.revokeEgress) > 0 {
+		log.Printf("Removing %d egress rules from %s", len(p.revokeEgress), sgid)
+
+		_, err := ec2Client.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+			GroupId:              aws.String(sgid),
+			SecurityGroupRuleIds: p.revokeEgress,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(p.revokeIngress) > 0 {
+		log.Printf("Removing %d ingress rules from %s", len(p.revokeIngress), sgid)
+
+		_, err := ec2Client.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String(sgid),
+			SecurityGroupRuleIds: p.revokeIngress,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+)
+
+func securityGroupRule(id, cidr, description string, tags map[string]string) *ec2.SecurityGroupRule {
+	sgr := &ec2.SecurityGroupRule{
+		SecurityGroupRuleId: aws.String(id),
+		IsEgress:            aws.Bool(true),
+		IpProtocol:          aws.String(ProtocolTCP),
+		FromPort:            aws.Int64(443),
+		ToPort:              aws.Int64(443),
+		CidrIpv4:            aws.String(cidr),
+		Description:         aws.String(description),
+	}
+	for key, value := range tags {
+		sgr.Tags = append(sgr.Tags, &ec2.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	return sgr
+}
+
+func TestSyncTagged(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+	}
+	defer func() { now = time.Now }()
+
+	rules := []Rule{
+		{
+			Name:       "api.foo.com",
+			Port:       443,
+			Protocol:   ProtocolTCP,
+			Egress:     true,
+			CIDRs:      []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.3/32", "10.0.0.4/32"},
+			SourceType: "dns",
+		},
+	}
+
+	existing := []*ec2.SecurityGroupRule{
+		securityGroupRule("sgr-1", "10.0.0.1/32", "AUTOGENERATED[payments]: api.foo.com", map[string]string{TagOwner: "payments"}),
+		securityGroupRule("sgr-2", "10.0.0.9/32", "AUTOGENERATED[payments]: api.foo.com", map[string]string{TagOwner: "payments"}),
+		securityGroupRule("sgr-3", "10.0.0.2/32", "AUTOGENERATED: api.foo.com", nil),
+		securityGroupRule("sgr-4", "10.0.0.3/32", "AUTOGENERATED[search]: api.foo.com", map[string]string{TagOwner: "search"}),
+		securityGroupRule("sgr-5", "10.0.0.8/32", "AUTOGENERATED[search]: api.bar.com", map[string]string{TagOwner: "search"}),
+	}
+
+	tests := []struct {
+		name      string
+		opts      Options
+		ec2Client *mockEC2Client
+
+		expectErr        bool
+		expectEgressCall *ec2.AuthorizeSecurityGroupEgressInput
+		expectRevokeCall *ec2.RevokeSecurityGroupEgressInput
+		expectCreateTags []*ec2.CreateTagsInput
+		expectModify     []*ec2.ModifySecurityGroupRulesInput
+	}{
+		{
+			name: "Sync",
+			opts: Options{Owner: "payments"},
+			ec2Client: &mockEC2Client{
+				SecurityGroupRules: existing,
+			},
+
+			expectEgressCall: &ec2.AuthorizeSecurityGroupEgressInput{
+				GroupId: aws.String("sg-123"),
+				IpPermissions: []*ec2.IpPermission{
+					{
+						FromPort:   aws.Int64(443),
+						ToPort:     aws.Int64(443),
+						IpProtocol: aws.String(ProtocolTCP),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("10.0.0.4/32"),
+								Description: aws.String("AUTOGENERATED[payments]: api.foo.com"),
+							},
+						},
+					},
+				},
+				TagSpecifications: []*ec2.TagSpecification{
+					{
+						ResourceType: aws.String(ec2.ResourceTypeSecurityGroupRule),
+						Tags: []*ec2.Tag{
+							{Key: aws.String(TagOwner), Value: aws.String("payments")},
+							{Key: aws.String(TagRule), Value: aws.String("api.foo.com")},
+							{Key: aws.String(TagFirstSeen), Value: aws.String("2021-07-01T12:00:00Z")},
+							{Key: aws.String(TagLastSeen), Value: aws.String("2021-07-01T12:00:00Z")},
+							{Key: aws.String(TagSourceType), Value: aws.String("dns")},
+						},
+					},
+				},
+			},
+			expectRevokeCall: &ec2.RevokeSecurityGroupEgressInput{
+				GroupId:              aws.String("sg-123"),
+				SecurityGroupRuleIds: []*string{aws.String("sgr-2")},
+			},
+			expectCreateTags: []*ec2.CreateTagsInput{
+				{
+					Resources: []*string{aws.String("sgr-3")},
+					Tags: []*ec2.Tag{
+						{Key: aws.String(TagOwner), Value: aws.String("payments")},
+						{Key: aws.String(TagRule), Value: aws.String("api.foo.com")},
+						{Key: aws.String(TagFirstSeen), Value: aws.String("2021-07-01T12:00:00Z")},
+						{Key: aws.String(TagLastSeen), Value: aws.String("2021-07-01T12:00:00Z")},
+						{Key: aws.String(TagSourceType), Value: aws.String("dns")},
+					},
+				},
+				{
+					Resources: []*string{aws.String("sgr-1")},
+					Tags: []*ec2.Tag{
+						{Key: aws.String(TagLastSeen), Value: aws.String("2021-07-01T12:00:00Z")},
+					},
+				},
+			},
+			expectModify: []*ec2.ModifySecurityGroupRulesInput{
+				{
+					GroupId: aws.String("sg-123"),
+					SecurityGroupRules: []*ec2.SecurityGroupRuleUpdate{
+						{
+							SecurityGroupRuleId: aws.String("sgr-3"),
+							SecurityGroupRule: &ec2.SecurityGroupRuleRequest{
+								CidrIpv4:    aws.String("10.0.0.2/32"),
+								Description: aws.String("AUTOGENERATED[payments]: api.foo.com"),
+								FromPort:    aws.Int64(443),
+								IpProtocol:  aws.String(ProtocolTCP),
+								ToPort:      aws.Int64(443),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ExceedsMaxShrink",
+			opts: Options{Owner: "payments", MaxShrinkPercent: 10},
+			ec2Client: &mockEC2Client{
+				SecurityGroupRules: existing,
+			},
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.ec2Client.AuthorizeSecurityGroupEgressCalls = make(chan *ec2.AuthorizeSecurityGroupEgressInput, 1)
+			test.ec2Client.RevokeSecurityGroupEgressCalls = make(chan *ec2.RevokeSecurityGroupEgressInput, 1)
+
+			err := SyncTagged(rules, "sg-123", test.opts, test.ec2Client)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				assert.Empty(t, test.ec2Client.CreateTagsCalls)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.EqualValues(t, test.expectEgressCall, <-test.ec2Client.AuthorizeSecurityGroupEgressCalls)
+			assert.EqualValues(t, test.expectRevokeCall, <-test.ec2Client.RevokeSecurityGroupEgressCalls)
+			assert.EqualValues(t, test.expectCreateTags, test.ec2Client.CreateTagsCalls)
+			assert.EqualValues(t, test.expectModify, test.ec2Client.ModifySecurityGroupRulesCalls)
+		})
+	}
+}
+
+func TestDescribeRulesUnsupported(t *testing.T) {
+	ec2Client := &mockEC2Client{
+		DescribeSecurityGroupRulesErr: awserr.New("InvalidAction", "The action DescribeSecurityGroupRules is not valid for this web service.", nil),
+	}
+
+	_, err := DescribeRules("sg-123", ec2Client)
+	assert.Equal(t, ErrTagsUnsupported, err)
+
+	ec2Client.DescribeSecurityGroupRulesErr = awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil)
+
+	_, err = DescribeRules("sg-123", ec2Client)
+	assert.Error(t, err)
+	assert.NotEqual(t, ErrTagsUnsupported, err)
+}
@@ -0,0 +1,264 @@
+package rule
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/networkfirewall"
+	"github.com/aws/aws-sdk-go/service/networkfirewall/networkfirewalliface"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/route53resolver/route53resolveriface"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awshelpers"
+)
+
+// cloudFrontRegion is the region of the WAFv2 API for CloudFront IP sets.
+const cloudFrontRegion = "us-east-1"
+
+// Target is a resource which rules are applied to, such as a security group
+// or a managed prefix list.
+type Target interface {
+	// Apply reconciles the target with the rules. The rules must already be
+	// resolved.
+	Apply(rules []Rule, opts Options) error
+
+	// Plan returns the changes which Apply would make, without making
+	// them. If Apply would refuse to make the changes, such as when they
+	// exceed MaxShrinkPercent, the changes are returned with the error.
+	Plan(rules []Rule, opts Options) ([]Change, error)
+
+	// String returns the ID of the target.
+	String() string
+}
+
+// Targets is the JSON configuration of the targets to apply rules to, as
+// embedded in lambda events.
+type Targets struct {
+	// SecurityGroups are the IDs of security groups.
+	SecurityGroups []string `json:"securityGroups"`
+
+	// PrefixLists are the IDs of customer-managed prefix lists.
+	PrefixLists []string `json:"prefixLists,omitempty"`
+
+	// NetworkACLs are network ACLs, and the rule numbers reserved in them.
+	NetworkACLs []NetworkACL `json:"networkAcls,omitempty"`
+
+	// WAFIPSets are WAFv2 IP sets.
+	WAFIPSets []WAFIPSet `json:"wafIpSets,omitempty"`
+
+	// DomainLists are the IDs of Route 53 Resolver DNS Firewall domain
+	// lists, which receive the names of DNS rules.
+	DomainLists []string `json:"domainLists,omitempty"`
+
+	// RuleGroups are the ARNs of Network Firewall stateful domain list rule
+	// groups, which receive the names of DNS rules.
+	RuleGroups []string `json:"ruleGroups,omitempty"`
+}
+
+// Clients are the AWS clients used by targets.
+type Clients struct {
+	EC2 ec2iface.EC2API
+
+	// WAFV2 manages REGIONAL IP sets, and WAFV2CloudFront manages
+	// CLOUDFRONT IP sets.
+	WAFV2           wafv2iface.WAFV2API
+	WAFV2CloudFront wafv2iface.WAFV2API
+
+	Route53Resolver route53resolveriface.Route53ResolverAPI
+	NetworkFirewall networkfirewalliface.NetworkFirewallAPI
+}
+
+// NewClients creates the clients used by targets.
+func NewClients(sess *session.Session) Clients {
+	return Clients{
+		EC2:             ec2.New(sess),
+		WAFV2:           wafv2.New(sess),
+		WAFV2CloudFront: wafv2.New(sess, aws.NewConfig().WithRegion(cloudFrontRegion)),
+		Route53Resolver: route53resolver.New(sess),
+		NetworkFirewall: networkfirewall.New(sess),
+	}
+}
+
+// Build returns the configured targets.
+func (t *Targets) Build(clients Clients) []Target {
+	targets := make([]Target, 0)
+
+	for _, id := range t.SecurityGroups {
+		targets = append(targets, &SecurityGroup{ID: id, Client: clients.EC2})
+	}
+	for _, id := range t.PrefixLists {
+		targets = append(targets, &PrefixList{ID: id, Client: clients.EC2})
+	}
+	for i := range t.NetworkACLs {
+		acl := t.NetworkACLs[i]
+		acl.Client = clients.EC2
+		targets = append(targets, &acl)
+	}
+	for i := range t.WAFIPSets {
+		ipSet := t.WAFIPSets[i]
+		ipSet.Client = clients.WAFV2
+		if ipSet.Scope == wafv2.ScopeCloudfront {
+			ipSet.Client = clients.WAFV2CloudFront
+		}
+		targets = append(targets, &ipSet)
+	}
+	for _, id := range t.DomainLists {
+		targets = append(targets, &DomainList{ID: id, Client: clients.Route53Resolver})
+	}
+	for _, arn := range t.RuleGroups {
+		targets = append(targets, &RuleGroup{ARN: arn, Client: clients.NetworkFirewall})
+	}
+
+	return targets
+}
+
+// ValidateDomainTargets returns an error if the targets include domain lists
+// or rule groups, which only receive the names of DNS rules, but there are no
+// DNS rules and opts do not allow the targets to be emptied.
+func (t *Targets) ValidateDomainTargets(hasDNSRules bool, opts Options) error {
+	if hasDNSRules || opts.AllowEmptyDomains || (len(t.DomainLists) == 0 && len(t.RuleGroups) == 0) {
+		return nil
+	}
+	return errors.New("domainLists and ruleGroups only receive the names of dns rules, and there are none")
+}
+
+// SecurityGroup is a security group target.
+type SecurityGroup struct {
+	ID     string
+	Client ec2iface.EC2API
+}
+
+// Apply adds rules to the security group and removes the stale rules
+// belonging to the owner.
+func (t *SecurityGroup) Apply(rules []Rule, opts Options) error {
+	return Apply(rules, t.ID, opts, t.Client)
+}
+
+// Plan returns the changes which Apply would make to the security group.
+func (t *SecurityGroup) Plan(rules []Rule, opts Options) ([]Change, error) {
+	if opts.UseTags {
+		existing, err := DescribeRules(t.ID, t.Client)
+		if err == nil {
+			p := planTagged(rules, existing, opts.Owner)
+			return p.changes(t.ID, opts.Owner, existing),
+				checkShrink(len(p.revokeEgress)+len(p.revokeIngress), p.owned, t.ID, opts.MaxShrinkPercent)
+		}
+		if err != ErrTagsUnsupported {
+			return nil, err
+		}
+	}
+
+	sg, err := awshelpers.DescribeSecurityGroup(t.ID, t.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes(rules, sg, opts.Owner), CheckShrink(rules, sg, opts.Owner, opts.MaxShrinkPercent)
+}
+
+func (t *SecurityGroup) String() string {
+	return t.ID
+}
+
+// Entry is a CIDR in a target which holds a set of CIDRs rather than
+// rules, such as a managed prefix list.
+type Entry struct {
+	CIDR string
+
+	// Description is the description of the entry, if the target supports
+	// them.
+	Description *string
+}
+
+// Entries returns the unique CIDRs of rules as entries, sorted by CIDR. Ports,
+// protocols and directions are ignored. Each entry is described with the name
+// of the first rule it belongs to.
+func Entries(rules []Rule, owner string) []Entry {
+	seen := make(map[string]bool)
+	entries := make([]Entry, 0)
+
+	for _, rule := range rules {
+		for _, cidr := range rule.CIDRs {
+			if seen[cidr] {
+				continue
+			}
+			seen[cidr] = true
+
+			entries = append(entries, Entry{
+				CIDR:        cidr,
+				Description: aws.String(Description(owner, rule.Name)),
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CIDR < entries[j].CIDR
+	})
+
+	return entries
+}
+
+// Plan is the set of changes which reconcile a target with its desired
+// entries.
+type Plan struct {
+	// Add are the desired entries which do not exist.
+	Add []Entry
+
+	// Remove are the existing entries belonging to the owner which are not
+	// desired.
+	Remove []Entry
+
+	// Owned is the number of existing entries belonging to the owner.
+	Owned int
+}
+
+// PlanEntries compares desired entries with the existing entries of a target.
+// Only existing entries for which owned returns true are removed.
+func PlanEntries(desired, existing []Entry, owned func(Entry) bool) *Plan {
+	p := &Plan{
+		Add:    make([]Entry, 0),
+		Remove: make([]Entry, 0),
+	}
+
+	exists := make(map[string]bool)
+	for _, entry := range existing {
+		exists[entry.CIDR] = true
+	}
+
+	wanted := make(map[string]bool)
+	for _, entry := range desired {
+		wanted[entry.CIDR] = true
+		if !exists[entry.CIDR] {
+			p.Add = append(p.Add, entry)
+		}
+	}
+
+	for _, entry := range existing {
+		if !owned(entry) {
+			continue
+		}
+		p.Owned++
+
+		if !wanted[entry.CIDR] {
+			p.Remove = append(p.Remove, entry)
+		}
+	}
+
+	return p
+}
+
+// Empty returns a boolean for whether the plan makes no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Add) == 0 && len(p.Remove) == 0
+}
+
+// CheckShrink returns an error if the plan removes more than maxPercent of
+// the entries belonging to the owner. A maxPercent of zero disables the check.
+func (p *Plan) CheckShrink(target string, maxPercent float64) error {
+	return checkShrink(len(p.Remove), p.Owned, target, maxPercent)
+}
@@ -0,0 +1,167 @@
+package rule
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
+)
+
+// WAF IP set limits.
+const (
+	// wafMaxAddresses is the largest number of addresses in an IP set.
+	wafMaxAddresses = 10000
+
+	// wafAttempts is the number of times an IP set is read and updated
+	// before giving up on concurrent updates.
+	wafAttempts = 5
+)
+
+// WAFIPSet is a WAFv2 IP set target. IP sets have no per-address
+// descriptions, so the target owns every address in the IP set and the owner
+// is ignored. An IP set holds either IPv4 or IPv6 addresses, and only the
+// CIDRs of that family are added to it.
+type WAFIPSet struct {
+	// Name and ID identify the IP set.
+	Name string `json:"name"`
+	ID   string `json:"id"`
+
+	// Scope is either REGIONAL (default) or CLOUDFRONT.
+	Scope string `json:"scope"`
+
+	Client wafv2iface.WAFV2API `json:"-"`
+}
+
+func (t *WAFIPSet) String() string {
+	return t.Name + "/" + t.ID
+}
+
+// scope returns the scope of the IP set.
+func (t *WAFIPSet) scope() string {
+	if t.Scope == "" {
+		return wafv2.ScopeRegional
+	}
+	return t.Scope
+}
+
+// Apply updates the addresses in the IP set. Every update is made with the
+// lock token of the IP set which was read, and the IP set is read again if
+// another writer updates it first.
+func (t *WAFIPSet) Apply(rules []Rule, opts Options) error {
+	for attempt := 0; attempt < wafAttempts; attempt++ {
+		err := t.reconcile(rules, opts)
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == wafv2.ErrCodeWAFOptimisticLockException {
+			log.Printf("IP set %s was updated concurrently: %v", t, err)
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("IP set %s was updated concurrently %d times", t, wafAttempts)
+}
+
+// Plan returns the changes which Apply would make to the IP set.
+func (t *WAFIPSet) Plan(rules []Rule, opts Options) ([]Change, error) {
+	_, desired, p, err := t.plan(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := entryChanges(t.String(), p)
+	if p.Empty() {
+		return changes, nil
+	}
+
+	if err := p.CheckShrink(t.String(), opts.MaxShrinkPercent); err != nil {
+		return changes, err
+	}
+
+	return changes, t.checkSize(desired)
+}
+
+// checkSize returns an error if the desired addresses exceed the limit of an
+// IP set.
+func (t *WAFIPSet) checkSize(desired []Entry) error {
+	if len(desired) > wafMaxAddresses {
+		return fmt.Errorf("IP set %s: %d addresses exceed the limit of %d", t, len(desired), wafMaxAddresses)
+	}
+	return nil
+}
+
+// plan reads the IP set, and plans the addresses of its IP version.
+func (t *WAFIPSet) plan(rules []Rule) (*wafv2.GetIPSetOutput, []Entry, *Plan, error) {
+	res, err := t.Client.GetIPSet(&wafv2.GetIPSetInput{
+		Id:    aws.String(t.ID),
+		Name:  aws.String(t.Name),
+		Scope: aws.String(t.scope()),
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	version := "IPv4"
+	if aws.StringValue(res.IPSet.IPAddressVersion) == wafv2.IPAddressVersionIpv6 {
+		version = "IPv6"
+	}
+
+	desired := make([]Entry, 0)
+	for _, entry := range Entries(rules, "") {
+		if family(entry.CIDR) == version {
+			desired = append(desired, Entry{CIDR: entry.CIDR})
+		}
+	}
+
+	existing := make([]Entry, len(res.IPSet.Addresses))
+	for i, address := range res.IPSet.Addresses {
+		existing[i] = Entry{CIDR: aws.StringValue(address)}
+	}
+
+	p := PlanEntries(desired, existing, func(Entry) bool {
+		return true
+	})
+
+	return res, desired, p, nil
+}
+
+// reconcile reads the IP set and applies one plan to it.
+func (t *WAFIPSet) reconcile(rules []Rule, opts Options) error {
+	res, desired, p, err := t.plan(rules)
+	if err != nil {
+		return err
+	}
+
+	if p.Empty() {
+		log.Printf("IP set %s is already in sync", t)
+		return nil
+	}
+
+	if err := p.CheckShrink(t.String(), opts.MaxShrinkPercent); err != nil {
+		return err
+	}
+
+	if err := t.checkSize(desired); err != nil {
+		return err
+	}
+
+	addresses := make([]string, len(desired))
+	for i, entry := range desired {
+		addresses[i] = entry.CIDR
+	}
+	sort.Strings(addresses)
+
+	log.Printf("Removing %d and adding %d addresses in IP set %s", len(p.Remove), len(p.Add), t)
+
+	_, err = t.Client.UpdateIPSet(&wafv2.UpdateIPSetInput{
+		Addresses:   aws.StringSlice(addresses),
+		Description: res.IPSet.Description,
+		Id:          aws.String(t.ID),
+		LockToken:   res.LockToken,
+		Name:        aws.String(t.Name),
+		Scope:       aws.String(t.scope()),
+	})
+	return err
+}
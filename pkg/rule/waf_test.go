@@ -0,0 +1,164 @@
+package rule
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWAFIPSetApply(t *testing.T) {
+	rules := []Rule{
+		{Name: "S3", Port: 443, CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24", "2001:db8::/32"}},
+		{Name: "S3", Port: 80, CIDRs: []string{"10.0.1.0/24"}},
+	}
+
+	tests := []struct {
+		name   string
+		rules  []Rule
+		opts   Options
+		client *fakeWAFClient
+
+		expectErr       bool
+		expectAddresses []string
+		expectUpdates   int
+	}{
+		{
+			name:   "AddAndRemove",
+			rules:  rules,
+			client: newFakeWAFClient(wafv2.IPAddressVersionIpv4, "10.0.1.0/24", "10.0.2.0/24"),
+
+			expectAddresses: []string{"10.0.0.0/24", "10.0.1.0/24"},
+			expectUpdates:   1,
+		},
+		{
+			name:   "InSync",
+			rules:  rules,
+			client: newFakeWAFClient(wafv2.IPAddressVersionIpv4, "10.0.0.0/24", "10.0.1.0/24"),
+
+			expectAddresses: []string{"10.0.0.0/24", "10.0.1.0/24"},
+		},
+		{
+			name:   "IPv6",
+			rules:  rules,
+			client: newFakeWAFClient(wafv2.IPAddressVersionIpv6),
+
+			expectAddresses: []string{"2001:db8::/32"},
+			expectUpdates:   1,
+		},
+		{
+			name:  "ConcurrentUpdate",
+			rules: rules,
+			client: func() *fakeWAFClient {
+				c := newFakeWAFClient(wafv2.IPAddressVersionIpv4)
+				c.conflicts = 2
+				return c
+			}(),
+
+			expectAddresses: []string{"10.0.0.0/24", "10.0.1.0/24"},
+			expectUpdates:   1,
+		},
+		{
+			name:  "PersistentConcurrentUpdate",
+			rules: rules,
+			client: func() *fakeWAFClient {
+				c := newFakeWAFClient(wafv2.IPAddressVersionIpv4)
+				c.conflicts = wafAttempts
+				return c
+			}(),
+
+			expectErr: true,
+		},
+		{
+			name:   "ExceedsMaxShrink",
+			opts:   Options{MaxShrinkPercent: 10},
+			client: newFakeWAFClient(wafv2.IPAddressVersionIpv4, "10.0.0.0/24"),
+
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &WAFIPSet{Name: "egress", ID: "abc123", Client: test.client}
+
+			err := target.Apply(test.rules, test.opts)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.expectAddresses, test.client.addresses)
+			assert.Equal(t, test.expectUpdates, test.client.updates)
+			assert.Equal(t, "managed by dsg", test.client.description)
+		})
+	}
+}
+
+// fakeWAFClient is an in-memory regional IP set.
+type fakeWAFClient struct {
+	wafv2iface.WAFV2API
+
+	version     string
+	lockToken   int
+	description string
+	addresses   []string
+
+	// conflicts is the number of updates to fail with a stale lock token,
+	// as if another writer updated the IP set.
+	conflicts int
+
+	// updates is the number of successful updates.
+	updates int
+}
+
+func newFakeWAFClient(version string, addresses ...string) *fakeWAFClient {
+	return &fakeWAFClient{
+		version:     version,
+		description: "managed by dsg",
+		addresses:   addresses,
+	}
+}
+
+func (c *fakeWAFClient) GetIPSet(input *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, error) {
+	if aws.StringValue(input.Scope) != wafv2.ScopeRegional {
+		return nil, awserr.New(wafv2.ErrCodeWAFNonexistentItemException, "IP set not found", nil)
+	}
+
+	return &wafv2.GetIPSetOutput{
+		IPSet: &wafv2.IPSet{
+			Addresses:        aws.StringSlice(c.addresses),
+			Description:      aws.String(c.description),
+			IPAddressVersion: aws.String(c.version),
+			Id:               input.Id,
+			Name:             input.Name,
+		},
+		LockToken: aws.String(strconv.Itoa(c.lockToken)),
+	}, nil
+}
+
+func (c *fakeWAFClient) UpdateIPSet(input *wafv2.UpdateIPSetInput) (*wafv2.UpdateIPSetOutput, error) {
+	if c.conflicts > 0 {
+		c.conflicts--
+		c.lockToken++
+	}
+
+	if aws.StringValue(input.LockToken) != strconv.Itoa(c.lockToken) {
+		return nil, awserr.New(wafv2.ErrCodeWAFOptimisticLockException, "The lock token is stale", nil)
+	}
+
+	c.lockToken++
+	c.description = aws.StringValue(input.Description)
+	c.addresses = aws.StringValueSlice(input.Addresses)
+	c.updates++
+
+	return &wafv2.UpdateIPSetOutput{
+		NextLockToken: aws.String(strconv.Itoa(c.lockToken)),
+	}, nil
+}
@@ -0,0 +1,82 @@
+// Package selector matches names against glob and regular expression
+// patterns.
+package selector
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Selector matches names, such as regions or service tags. A selector is
+// either a shell style glob, such as "us-*", or a regular expression enclosed
+// in slashes, such as "/^us-(east|west)-[12]$/". Globs do not match across
+// "/" separators.
+type Selector struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// New parses a selector pattern.
+func New(pattern string) (Selector, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return Selector{}, fmt.Errorf("invalid selector %q: %v", pattern, err)
+		}
+
+		return Selector{pattern: pattern, re: re}, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return Selector{}, fmt.Errorf("invalid selector %q: %v", pattern, err)
+	}
+
+	return Selector{pattern: pattern}, nil
+}
+
+// NewList parses a list of selector patterns.
+func NewList(patterns []string) ([]Selector, error) {
+	selectors := make([]Selector, len(patterns))
+	for i := range patterns {
+		var err error
+		if selectors[i], err = New(patterns[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return selectors, nil
+}
+
+// Pattern returns the pattern the selector was created from.
+func (s Selector) Pattern() string {
+	return s.pattern
+}
+
+// Literal returns a boolean for whether the selector only matches its own
+// pattern.
+func (s Selector) Literal() bool {
+	return s.re == nil && !strings.ContainsAny(s.pattern, `*?[\`)
+}
+
+// Match returns a boolean for whether the selector matches a name.
+func (s Selector) Match(name string) bool {
+	if s.re != nil {
+		return s.re.MatchString(name)
+	}
+
+	// The pattern was validated by New.
+	ok, _ := path.Match(s.pattern, name)
+	return ok
+}
+
+// MatchAny returns a boolean for whether any selector matches a name.
+func MatchAny(selectors []Selector, name string) bool {
+	for _, s := range selectors {
+		if s.Match(name) {
+			return true
+		}
+	}
+	return false
+}
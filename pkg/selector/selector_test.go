@@ -0,0 +1,55 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+
+		expect  bool
+		literal bool
+	}{
+		{pattern: "us-east-1", name: "us-east-1", expect: true, literal: true},
+		{pattern: "us-east-1", name: "us-east-2", expect: false, literal: true},
+		{pattern: "us-*", name: "us-west-2", expect: true},
+		{pattern: "us-*", name: "eu-west-1", expect: false},
+		{pattern: "us-?ast-[12]", name: "us-east-2", expect: true},
+		{pattern: "S3/*", name: "S3/us-east-1", expect: true},
+		{pattern: "*", name: "S3/us-east-1", expect: false},
+		{pattern: "/^us-(east|west)-2$/", name: "us-west-2", expect: true},
+		{pattern: "/^us-(east|west)-2$/", name: "us-west-1", expect: false},
+		{pattern: "/west/", name: "eu-west-1", expect: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern+"_"+test.name, func(t *testing.T) {
+			s, err := New(test.pattern)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, s.Match(test.name))
+			assert.Equal(t, test.literal, s.Literal())
+			assert.Equal(t, test.pattern, s.Pattern())
+		})
+	}
+}
+
+func TestNewListError(t *testing.T) {
+	for _, pattern := range []string{"/(/", "[", "us-[east"} {
+		_, err := NewList([]string{"us-east-1", pattern})
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	selectors, err := NewList([]string{"us-*", "/^eu-/"})
+	assert.NoError(t, err)
+
+	assert.True(t, MatchAny(selectors, "us-east-1"))
+	assert.True(t, MatchAny(selectors, "eu-west-1"))
+	assert.False(t, MatchAny(selectors, "ap-south-1"))
+	assert.False(t, MatchAny(nil, "us-east-1"))
+}
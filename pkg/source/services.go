@@ -0,0 +1,90 @@
+package source
+
+import (
+	"fmt"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// AWSServices are the AWS services and regions of an aws-api-egress event.
+type AWSServices struct {
+	// Services are AWS services to whitelist. A service is either a name,
+	// which allows HTTPS egress, or an object with a name, ports, protocol
+	// and direction.
+	// See  https://docs.aws.amazon.com/general/latest/gr/aws-ip-ranges.html
+	// for a complete list of services.
+	Services []awsips.Service `json:"services"`
+
+	// Regions are the regions to whitelist. Regions may be globs such as
+	// "us-*" or regular expressions enclosed in slashes. The "@current"
+	// region is the region the function is running in.
+	Regions []string `json:"regions"`
+
+	// Config configures the IP ranges file.
+	awsips.Config
+}
+
+// Resolve validates the services, and generates their rules from the IP
+// ranges file at url. The getter the IP ranges were read with is returned, so
+// callers can report where they came from. opts configure the getter after
+// the configured options, so a digest from an AmazonIpSpaceChanged
+// notification replaces a configured one.
+func (s *AWSServices) Resolve(url, currentRegion string, opts ...awsips.Option) ([]rule.Rule, *awsips.IPRangesGetter, error) {
+	regions, err := awsips.ResolveRegions(s.Regions, currentRegion, s.PairedRegions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve regions: %v", err)
+	}
+
+	getter := awsips.NewIPRangesGetter(url, regions, append(s.Config.Options(), opts...)...)
+
+	ranges, err := getter.Get()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get IP ranges: %v", err)
+	}
+
+	if err := validateServices(s.Services, getter); err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := serviceRules(s.Services, getter, ranges.SyncToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rules, getter, nil
+}
+
+// validateServices returns an error if a service is not in the IP ranges file
+// or has an invalid rule configuration.
+func validateServices(services []awsips.Service, getter *awsips.IPRangesGetter) error {
+	for _, svc := range services {
+		if err := svc.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return getter.Validate(awsips.ServiceNames(services))
+}
+
+// serviceRules generates the rules of the services from the IP ranges with
+// syncToken.
+func serviceRules(services []awsips.Service, getter *awsips.IPRangesGetter, syncToken string) ([]rule.Rule, error) {
+	rules := make([]rule.Rule, 0)
+
+	for _, svc := range services {
+		cidrs, err := getter.GetService(svc.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CIDRs for service %s: %v", svc.Name, err)
+		}
+
+		rules = append(rules, svc.Rules(cidrs)...)
+	}
+
+	for i := range rules {
+		rules[i].SourceType = TypeAWSService
+		rules[i].SyncToken = syncToken
+	}
+
+	return rules, nil
+}
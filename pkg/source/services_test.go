@@ -0,0 +1,79 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAWSServicesResolve(t *testing.T) {
+	s3CIDRs := []string{"52.218.128.0/17", "52.92.32.0/22", "54.231.160.0/19"}
+
+	tests := []struct {
+		name     string
+		services AWSServices
+		opts     []awsips.Option
+
+		expect []rule.Rule
+		err    bool
+	}{
+		{
+			name:     "Services",
+			services: AWSServices{Services: []awsips.Service{{Name: "S3", Ports: []int{443, 8443}}}, Regions: []string{"@current"}},
+			expect: []rule.Rule{
+				{Name: "S3", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: s3CIDRs, SourceType: TypeAWSService, SyncToken: "1549989079"},
+				{Name: "S3", Port: 8443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: s3CIDRs, SourceType: TypeAWSService, SyncToken: "1549989079"},
+			},
+		},
+		{
+			name:     "UnknownService",
+			services: AWSServices{Services: []awsips.Service{{Name: "NOPE"}}, Regions: []string{"@current"}},
+			err:      true,
+		},
+		{
+			name:     "InvalidPort",
+			services: AWSServices{Services: []awsips.Service{{Name: "S3", Ports: []int{0}}}, Regions: []string{"@current"}},
+			err:      true,
+		},
+		{
+			name:     "InvalidRegion",
+			services: AWSServices{Services: []awsips.Service{{Name: "S3"}}, Regions: []string{"/[/"}},
+			err:      true,
+		},
+		{
+			name: "DigestMismatch",
+			services: AWSServices{
+				Services: []awsips.Service{{Name: "S3"}},
+				Regions:  []string{"@current"},
+				Config:   awsips.Config{MD5: "00000000000000000000000000000000"},
+			},
+			err: true,
+		},
+		{
+			name: "NotificationDigest",
+			services: AWSServices{
+				Services: []awsips.Service{{Name: "S3"}},
+				Regions:  []string{"@current"},
+				Config:   awsips.Config{MD5: "00000000000000000000000000000000"},
+			},
+			opts:   []awsips.Option{awsips.WithMD5("2182bff9048fe44d4c1d9b37903fc632")},
+			expect: []rule.Rule{{Name: "S3", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: s3CIDRs, SourceType: TypeAWSService, SyncToken: "1549989079"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, getter, err := test.services.Resolve("file://../awsips/testdata/ip-ranges.json", "us-west-2", test.opts...)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, rules)
+			assert.Equal(t, "file://../awsips/testdata/ip-ranges.json", getter.Source())
+		})
+	}
+}
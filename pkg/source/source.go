@@ -0,0 +1,241 @@
+// Package source resolves typed rule sources, such as DNS names, AWS service
+// IP ranges and static CIDRs, into one list of rules.
+package source
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+)
+
+// Source types.
+const (
+	TypeDNS        = rule.SourceTypeDNS
+	TypeAWSService = "awsService"
+	TypeStatic     = "static"
+)
+
+// Source is a source of CIDRs and the rules to create for them.
+type Source struct {
+	// Type is the type of source: dns, awsService or static.
+	Type string `json:"type"`
+
+	// Name is the FQDN of a dns source, the service of an awsService source,
+	// or a label for a static source. It is used in rule descriptions.
+	Name string `json:"name"`
+
+	// Regions are the regions of an awsService source. Regions may be globs
+	// such as "us-*" or regular expressions enclosed in slashes. The
+	// "@current" region is the region the caller is running in.
+	Regions []string `json:"regions,omitempty"`
+
+	// CIDRs are the IPv4 CIDRs of a static source.
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// Ports are the ports to allow traffic to. Defaults to 443.
+	Ports []int `json:"ports,omitempty"`
+
+	// Protocol is the network protocol. Defaults to tcp.
+	Protocol string `json:"protocol,omitempty"`
+
+	// Direction is either egress (default) or ingress.
+	Direction string `json:"direction,omitempty"`
+}
+
+// Validate returns an error if the source is not configured correctly.
+func (s *Source) Validate() error {
+	switch s.Type {
+	case TypeDNS, TypeAWSService:
+		if len(s.CIDRs) > 0 {
+			return fmt.Errorf("%s source %s: cidrs are only valid for static sources", s.Type, s.Name)
+		}
+	case TypeStatic:
+		if len(s.CIDRs) == 0 {
+			return fmt.Errorf("static source %s has no cidrs", s.Name)
+		}
+		for _, cidr := range s.CIDRs {
+			if err := checkIPv4(cidr); err != nil {
+				return fmt.Errorf("static source %s: %v", s.Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("source %s: unsupported type %q", s.Name, s.Type)
+	}
+
+	if len(s.Regions) > 0 && s.Type != TypeAWSService {
+		return fmt.Errorf("%s source %s: regions are only valid for awsService sources", s.Type, s.Name)
+	}
+
+	return s.service().Validate()
+}
+
+// service returns the rule configuration of the source.
+func (s *Source) service() awsips.Service {
+	return awsips.Service{
+		Name:      s.Name,
+		Ports:     s.Ports,
+		Protocol:  s.Protocol,
+		Direction: s.Direction,
+	}
+}
+
+// checkIPv4 returns an error if cidr is not an IPv4 CIDR.
+func checkIPv4(cidr string) error {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+	if ip.To4() == nil {
+		return fmt.Errorf("not an IPv4 CIDR: %s", cidr)
+	}
+	return nil
+}
+
+// HasDNS returns a boolean for whether any of the sources is a dns source.
+func HasDNS(sources []Source) bool {
+	for _, src := range sources {
+		if src.Type == TypeDNS {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolver resolves sources into rules.
+type Resolver struct {
+	// IPRanges configures the IP ranges file for awsService sources.
+	IPRanges awsips.Config
+
+	// CurrentRegion replaces "@current" in the regions of awsService
+	// sources.
+	CurrentRegion string
+
+	// LookupHost resolves dns sources. Defaults to net.LookupHost.
+	LookupHost func(host string) ([]string, error)
+
+	getters map[string]*awsips.IPRangesGetter
+}
+
+// Resolve validates and resolves every source, returning one rule per source
+// and port with its CIDRs populated. Either every source is resolved or an
+// error is returned, so the result always describes the complete desired
+// state.
+func (r *Resolver) Resolve(sources []Source) ([]rule.Rule, error) {
+	rules := make([]rule.Rule, 0)
+
+	for i := range sources {
+		src := &sources[i]
+		if err := src.Validate(); err != nil {
+			return nil, err
+		}
+
+		cidrs, syncToken, err := r.cidrs(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s source %s: %v", src.Type, src.Name, err)
+		}
+
+		for _, generated := range src.service().Rules(cidrs) {
+			generated.SourceType = src.Type
+			generated.SyncToken = syncToken
+			rules = append(rules, generated)
+		}
+	}
+
+	return rules, nil
+}
+
+// Options returns the options to apply the resolved rules with. No rules are
+// removed if the IP ranges of any awsService source came from the compiled-in
+// snapshot.
+func (r *Resolver) Options(opts rule.Options) rule.Options {
+	for _, getter := range r.getters {
+		if getter.Source() == awsips.SourceSnapshot {
+			return awsips.SnapshotOptions(getter.Source(), opts)
+		}
+	}
+
+	return opts
+}
+
+// cidrs returns the CIDRs of a source, and the syncToken of the IP ranges
+// they were read from, if any.
+func (r *Resolver) cidrs(src *Source) ([]string, string, error) {
+	switch src.Type {
+	case TypeDNS:
+		lookup := r.LookupHost
+		if lookup == nil {
+			lookup = net.LookupHost
+		}
+
+		ips, err := lookup(src.Name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		cidrs := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			// Security group IpRanges are IPv4 only.
+			if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() != nil {
+				cidrs = append(cidrs, ip+rule.CIDRSuffix)
+			}
+		}
+		if len(cidrs) == 0 {
+			return nil, "", errors.New("no IPv4 addresses")
+		}
+		sort.Strings(cidrs)
+
+		return cidrs, "", nil
+
+	case TypeAWSService:
+		getter, err := r.getter(src.Regions)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if err := getter.Validate([]string{src.Name}); err != nil {
+			return nil, "", err
+		}
+
+		ranges, err := getter.Get()
+		if err != nil {
+			return nil, "", err
+		}
+
+		cidrs, err := getter.GetService(src.Name)
+		return cidrs, ranges.SyncToken, err
+
+	default:
+		return src.CIDRs, "", nil
+	}
+}
+
+// getter returns the IP ranges getter for a list of regions. Getters are
+// shared between sources with the same regions.
+func (r *Resolver) getter(regions []string) (*awsips.IPRangesGetter, error) {
+	resolved, err := awsips.ResolveRegions(regions, r.CurrentRegion, r.IPRanges.PairedRegions)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.Join(resolved, ",")
+	if getter, ok := r.getters[key]; ok {
+		return getter, nil
+	}
+
+	getter := awsips.NewIPRangesGetter(r.IPRanges.Source(), resolved, r.IPRanges.Options()...)
+	if _, err := getter.Get(); err != nil {
+		return nil, err
+	}
+
+	if r.getters == nil {
+		r.getters = make(map[string]*awsips.IPRangesGetter)
+	}
+	r.getters[key] = getter
+
+	return getter, nil
+}
@@ -0,0 +1,131 @@
+package source
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jniedrauer/dynamic-security-groups/pkg/awsips"
+	"github.com/jniedrauer/dynamic-security-groups/pkg/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func lookupHost(host string) ([]string, error) {
+	switch host {
+	case "api.example.com":
+		return []string{"203.0.113.20", "2001:db8::1", "203.0.113.10"}, nil
+	case "v6.example.com":
+		return []string{"2001:db8::1"}, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestResolve(t *testing.T) {
+	file, err := filepath.Abs("../awsips/testdata/ip-ranges.json")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		sources []Source
+
+		expect []rule.Rule
+		err    bool
+	}{
+		{
+			name: "AllTypes",
+			sources: []Source{
+				{Type: TypeAWSService, Name: "S3", Regions: []string{"@current"}},
+				{Type: TypeDNS, Name: "api.example.com", Ports: []int{443, 8443}},
+				{Type: TypeStatic, Name: "office", CIDRs: []string{"198.51.100.0/24"}, Ports: []int{22}, Direction: awsips.DirectionIngress},
+			},
+			expect: []rule.Rule{
+				{Name: "S3", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"52.218.128.0/17", "52.92.32.0/22", "54.231.160.0/19"}, SourceType: TypeAWSService, SyncToken: "1549989079"},
+				{Name: "api.example.com", Port: 443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32", "203.0.113.20/32"}, SourceType: TypeDNS},
+				{Name: "api.example.com", Port: 8443, Protocol: rule.ProtocolTCP, Egress: true, CIDRs: []string{"203.0.113.10/32", "203.0.113.20/32"}, SourceType: TypeDNS},
+				{Name: "office", Port: 22, Protocol: rule.ProtocolTCP, Egress: false, CIDRs: []string{"198.51.100.0/24"}, SourceType: TypeStatic},
+			},
+		},
+		{
+			name:    "UnknownType",
+			sources: []Source{{Type: "feed", Name: "github"}},
+			err:     true,
+		},
+		{
+			name:    "UnknownService",
+			sources: []Source{{Type: TypeAWSService, Name: "NOPE", Regions: []string{"us-west-2"}}},
+			err:     true,
+		},
+		{
+			name:    "UnresolvableHost",
+			sources: []Source{{Type: TypeDNS, Name: "missing.example.com"}},
+			err:     true,
+		},
+		{
+			name:    "IPv6OnlyHost",
+			sources: []Source{{Type: TypeDNS, Name: "v6.example.com"}},
+			err:     true,
+		},
+		{
+			name:    "StaticWithoutCIDRs",
+			sources: []Source{{Type: TypeStatic, Name: "office"}},
+			err:     true,
+		},
+		{
+			name:    "StaticIPv6",
+			sources: []Source{{Type: TypeStatic, Name: "office", CIDRs: []string{"2001:db8::/32"}}},
+			err:     true,
+		},
+		{
+			name:    "RegionsOnDNS",
+			sources: []Source{{Type: TypeDNS, Name: "api.example.com", Regions: []string{"us-west-2"}}},
+			err:     true,
+		},
+		{
+			name:    "InvalidPort",
+			sources: []Source{{Type: TypeDNS, Name: "api.example.com", Ports: []int{0}}},
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := &Resolver{
+				IPRanges:      awsips.Config{URL: "file://" + file},
+				CurrentRegion: "us-west-2",
+				LookupHost:    lookupHost,
+			}
+
+			rules, err := resolver.Resolve(test.sources)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, rules)
+		})
+	}
+}
+
+func TestResolverOptions(t *testing.T) {
+	opts := rule.Options{Owner: "team-payments"}
+	sources := []Source{{Type: TypeAWSService, Name: "S3", Regions: []string{"@current"}}}
+
+	resolver := &Resolver{
+		IPRanges:      awsips.Config{URL: "file://../awsips/testdata/ip-ranges.json", SnapshotFallback: true},
+		CurrentRegion: "us-west-2",
+	}
+	_, err := resolver.Resolve(sources)
+	assert.NoError(t, err)
+	assert.Equal(t, opts, resolver.Options(opts))
+
+	// Rules from the compiled-in snapshot never remove rules.
+	resolver = &Resolver{
+		IPRanges:      awsips.Config{URL: "file://../awsips/testdata/missing.json", SnapshotFallback: true},
+		CurrentRegion: "us-west-2",
+	}
+	_, err = resolver.Resolve(sources)
+	assert.NoError(t, err)
+	assert.NotEqual(t, opts, resolver.Options(opts))
+	assert.True(t, resolver.Options(opts).MaxShrinkPercent > 0)
+}